@@ -0,0 +1,343 @@
+package cloudinary
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// ArchiveOptions selects the files of an archive and how they are packed. At least one of
+// Files, Prefixes or Tags is required.
+type ArchiveOptions struct {
+	Files    []string
+	Prefixes []string
+	// Tags can't be scoped to the root of the disk by the archive API, so they are only archived
+	// locally, with Fallback, when the disk has a root.
+	Tags []string
+	// TargetFormat is the archive format, "zip" (default) or "tgz".
+	TargetFormat string
+	// FlattenFolders puts all the files in the root of the archive.
+	FlattenFolders bool
+	// Transformation is applied to every image and video of the archive, e.g. "c_fill,w_300".
+	Transformation string
+	// ExpiresAt is when the archive URL expires, defaults to an hour.
+	ExpiresAt time.Time
+	// Fallback builds the zip locally from the downloaded files when the archive API refuses
+	// the request, e.g. because the archive exceeds the account limits.
+	Fallback bool
+}
+
+// Archive returns a signed URL that generates and downloads the archive.
+func (r *Cloudinary) Archive(options ArchiveOptions) (_ string, err error) {
+	r, op := r.startOperation("Archive")
+	defer func() { op.end(err) }()
+
+	return r.archiveURL(options)
+}
+
+// ArchiveTo streams the archive to the writer.
+func (r *Cloudinary) ArchiveTo(writer io.Writer, options ArchiveOptions) (err error) {
+	r, op := r.startOperation("ArchiveTo")
+	defer func() { op.end(err) }()
+
+	if options.Fallback && len(options.Tags) > 0 && validPath(r.root) != "" {
+		return r.localArchive(writer, options)
+	}
+
+	archiveURL, err := r.archiveURL(options)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return fmt.Errorf("error fetching archive: %w", err)
+	}
+	resp, err := r.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("error fetching archive: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := responseError("archive", resp); err != nil {
+		if options.Fallback {
+			return r.localArchive(writer, options)
+		}
+		return err
+	}
+
+	_, err = io.Copy(writer, resp.Body)
+	return err
+}
+
+func (r *Cloudinary) archiveURL(options ArchiveOptions) (string, error) {
+	params, err := r.archiveParams(options)
+	if err != nil {
+		return "", err
+	}
+	return r.instance.Upload.DownloadArchiveURL(params)
+}
+
+func (r *Cloudinary) archiveParams(options ArchiveOptions) (uploader.CreateArchiveParams, error) {
+	if len(options.Files) == 0 && len(options.Prefixes) == 0 && len(options.Tags) == 0 {
+		return uploader.CreateArchiveParams{}, fmt.Errorf("no files, prefixes or tags given for the archive")
+	}
+	if len(options.Tags) > 0 && validPath(r.root) != "" {
+		return uploader.CreateArchiveParams{}, fmt.Errorf("the archive API can't scope tags to the root %s of the disk, use ArchiveTo with Fallback", r.root)
+	}
+
+	// The files can be of any resource type, so they are fully qualified to be archived together.
+	var publicIDs []string
+	for _, file := range options.Files {
+		if err := r.ctx.Err(); err != nil {
+			return uploader.CreateArchiveParams{}, err
+		}
+		asset, err := r.getAsset(validPath(file))
+		if err != nil {
+			return uploader.CreateArchiveParams{}, err
+		}
+		publicIDs = append(publicIDs, fmt.Sprintf("%s/%s/%s", asset.ResourceType, asset.Type, asset.PublicID))
+	}
+
+	expiresAt := options.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(time.Hour)
+	}
+	targetFormat := options.TargetFormat
+	if targetFormat == "" {
+		targetFormat = uploader.Zip
+	}
+
+	return uploader.CreateArchiveParams{
+		ResourceType:            api.All,
+		Type:                    api.DeliveryType(r.deliveryType()),
+		FullyQualifiedPublicIDs: publicIDs,
+		Prefixes:                rootPaths(r.root, options.Prefixes),
+		Tags:                    options.Tags,
+		TargetFormat:            targetFormat,
+		FlattenFolders:          api.Bool(options.FlattenFolders),
+		Transformations:         options.Transformation,
+		ExpiresAt:               &expiresAt,
+	}, nil
+}
+
+// archiveEntry is a single file of a locally built archive.
+type archiveEntry struct {
+	publicID     string
+	resourceType string
+	deliveryType string
+	format       string
+	version      int
+}
+
+// asset returns the fields of the entry that build its url.
+func (r archiveEntry) asset() *uploader.ExplicitResult {
+	var asset uploader.ExplicitResult
+	asset.PublicID = r.publicID
+	asset.ResourceType = r.resourceType
+	asset.Type = r.deliveryType
+	asset.Format = r.format
+	asset.Version = r.version
+	return &asset
+}
+
+// localArchive zips the files one by one while they are downloaded.
+func (r *Cloudinary) localArchive(writer io.Writer, options ArchiveOptions) error {
+	if options.TargetFormat != "" && options.TargetFormat != uploader.Zip {
+		return fmt.Errorf("local archive only supports the zip format, got %s", options.TargetFormat)
+	}
+
+	entries, err := r.archiveEntries(options)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(writer)
+	for _, entry := range entries {
+		name, _ := relativePath(r.root, entry.publicID)
+		// Only the public IDs of raw files contain the extension.
+		if entry.resourceType != string(api.File) && entry.format != "" {
+			name += "." + entry.format
+		}
+		if options.FlattenFolders {
+			name = path.Base(name)
+		}
+
+		var url string
+		if options.Transformation != "" && entry.resourceType != string(api.File) {
+			url, err = r.transformedURL(entry, options.Transformation)
+		} else {
+			url, err = r.assetUrl(entry.asset())
+		}
+		if err != nil {
+			return err
+		}
+		if err := archiveFile(r.ctx, r.httpClient(), archive, name, url); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func (r *Cloudinary) archiveEntries(options ArchiveOptions) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	for _, file := range options.Files {
+		if err := r.ctx.Err(); err != nil {
+			return nil, err
+		}
+		asset, err := r.getAsset(validPath(file))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{
+			publicID:     asset.PublicID,
+			resourceType: asset.ResourceType,
+			deliveryType: asset.Type,
+			format:       asset.Format,
+			version:      asset.Version,
+		})
+	}
+
+	for _, assetType := range assetTypes {
+		for _, prefix := range options.Prefixes {
+			nextCursor := ""
+			for {
+				if err := r.ctx.Err(); err != nil {
+					return nil, err
+				}
+				response, err := r.instance.Admin.Assets(r.ctx, admin.AssetsParams{
+					Prefix:       rootPath(r.root, prefix),
+					DeliveryType: r.deliveryType(),
+					AssetType:    assetType,
+					MaxResults:   500,
+					NextCursor:   nextCursor,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if err := r.apiError("list files", response.Error); err != nil {
+					return nil, err
+				}
+				entries = append(entries, briefArchiveEntries(response.Assets)...)
+
+				nextCursor = response.NextCursor
+				if nextCursor == "" {
+					break
+				}
+			}
+		}
+		for _, tag := range options.Tags {
+			nextCursor := ""
+			for {
+				if err := r.ctx.Err(); err != nil {
+					return nil, err
+				}
+				response, err := r.instance.Admin.AssetsByTag(r.ctx, admin.AssetsByTagParams{
+					Tag:        tag,
+					AssetType:  assetType,
+					MaxResults: 500,
+					NextCursor: nextCursor,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if err := r.apiError("list files by tag", response.Error); err != nil {
+					return nil, err
+				}
+				// Tags are global to the account, so the files of other delivery types or outside of
+				// the root are skipped.
+				for _, asset := range response.Assets {
+					if asset.Type != r.deliveryType() {
+						continue
+					}
+					if _, ok := relativePath(r.root, asset.PublicID); ok {
+						entries = append(entries, briefArchiveEntries([]api.BriefAssetResult{asset})...)
+					}
+				}
+
+				nextCursor = response.NextCursor
+				if nextCursor == "" {
+					break
+				}
+			}
+		}
+	}
+
+	// A file can match several prefixes or tags.
+	seen := make(map[string]bool)
+	unique := entries[:0]
+	for _, entry := range entries {
+		key := entry.resourceType + "/" + entry.publicID
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, entry)
+		}
+	}
+	return unique, nil
+}
+
+func (r *Cloudinary) transformedURL(entry archiveEntry, transformation string) (string, error) {
+	asset, err := r.instance.Media(entry.publicID)
+	if err != nil {
+		return "", err
+	}
+	asset.AssetType = api.AssetType(entry.resourceType)
+	asset.DeliveryType = api.DeliveryType(r.deliveryType())
+	asset.Config.URL.SignURL = asset.Config.URL.SignURL || asset.DeliveryType != api.Upload
+	asset.Transformation = transformation
+	asset.Suffix = ""
+	url, err := asset.String()
+	if err != nil {
+		return "", err
+	}
+	if entry.format != "" && !strings.HasSuffix(url, "."+entry.format) {
+		url += "." + entry.format
+	}
+	return url, nil
+}
+
+func briefArchiveEntries(assets []api.BriefAssetResult) []archiveEntry {
+	entries := make([]archiveEntry, 0, len(assets))
+	for _, asset := range assets {
+		entries = append(entries, archiveEntry{
+			publicID:     asset.PublicID,
+			resourceType: asset.AssetType,
+			deliveryType: asset.Type,
+			format:       asset.Format,
+			version:      asset.Version,
+		})
+	}
+	return entries
+}
+
+func archiveFile(ctx context.Context, client *http.Client, archive *zip.Writer, name, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %w", name, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if err := responseError(fmt.Sprintf("fetch %s", name), resp); err != nil {
+		return err
+	}
+
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, resp.Body)
+	return err
+}
@@ -0,0 +1,62 @@
+package cloudinary
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveTagsRoot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1_1/cloud/resources/image/tags/logo"):
+			_, _ = w.Write([]byte(`{"resources":[
+				{"public_id":"goravel/logo","resource_type":"image","type":"upload","format":"png","version":1700000000,"secure_url":"https://res.cloudinary.com/cloud/image/upload/goravel/logo.png"},
+				{"public_id":"other/logo","resource_type":"image","type":"upload","format":"png"},
+				{"public_id":"goravel/private","resource_type":"image","type":"private","format":"png"}
+			]}`))
+		case strings.HasPrefix(r.URL.Path, "/v1_1/cloud/resources/"):
+			_, _ = w.Write([]byte(`{"resources":[]}`))
+		default:
+			// The file content is the path it was downloaded from.
+			_, _ = w.Write([]byte(r.URL.Path + " " + r.URL.Query().Get("public_id")))
+		}
+	}))
+	defer server.Close()
+
+	// The files are downloaded from the delivery domain of the disk, not from the url of the listing.
+	host := strings.TrimPrefix(server.URL, "http://")
+	driver := newServerDriver(t, server.URL, map[string]any{"root": "goravel", "cname": host, "secure": false})
+
+	_, err := driver.Archive(ArchiveOptions{Tags: []string{"logo"}})
+	assert.EqualError(t, err, "the archive API can't scope tags to the root goravel of the disk, use ArchiveTo with Fallback")
+
+	var buffer bytes.Buffer
+	assert.Nil(t, driver.ArchiveTo(&buffer, ArchiveOptions{Tags: []string{"logo"}, Fallback: true}))
+	assert.Equal(t, map[string]string{"logo.png": "/cloud/image/upload/v1700000000/goravel/logo.png "}, zipFiles(t, buffer.Bytes()))
+
+	// The originals of private files are only downloaded through the download API.
+	buffer.Reset()
+	assert.Nil(t, driver.WithDeliveryType("private").ArchiveTo(&buffer, ArchiveOptions{Tags: []string{"logo"}, Fallback: true}))
+	assert.Equal(t, map[string]string{"private.png": "/v1_1/cloud/image/download goravel/private"}, zipFiles(t, buffer.Bytes()))
+}
+
+func zipFiles(t *testing.T, archive []byte) map[string]string {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.Nil(t, err)
+	files := make(map[string]string)
+	for _, file := range reader.File {
+		content, err := file.Open()
+		assert.Nil(t, err)
+		body, err := io.ReadAll(content)
+		assert.Nil(t, err)
+		files[file.Name] = string(body)
+	}
+	return files
+}
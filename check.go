@@ -0,0 +1,163 @@
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/spf13/cast"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultPingTimeout is the timeout of the ping of a disk check when the disk doesn't set one.
+const defaultPingTimeout = 5 * time.Second
+
+// transformationParameter matches a parameter of a transformation, e.g. "c_fill" or "$width_300".
+var transformationParameter = regexp.MustCompile(`^\$?[a-z][a-z0-9]*_\S+$`)
+
+// Ping checks that the API is reachable with the credentials of the disk.
+func (r *Cloudinary) Ping() (err error) {
+	r, op := r.startOperation("Ping", attribute.String("cloudinary.disk", r.disk))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.Ping(r.ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("ping", result.Error); err != nil {
+		return err
+	}
+	if result.Status != "ok" {
+		return &APIError{Action: "ping", Message: fmt.Sprintf("unexpected status %q", result.Status)}
+	}
+	return nil
+}
+
+// ValidateDisk checks the config of a disk without calling the API: the credentials, the url, the
+// duplicate policy, and the syntax of the eager transformations of the disk and of its upload folders.
+func ValidateDisk(config config.Config, disk string) error {
+	var errs []error
+	if _, err := configuration(config, disk); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validDuplicatePolicy(DuplicatePolicy(config.GetString(fmt.Sprintf("filesystems.disks.%s.duplicates", disk)))); err != nil {
+		errs = append(errs, fmt.Errorf("invalid duplicates option for disk %s: %w", disk, err))
+	}
+
+	var eager []string
+	if value := config.Get(fmt.Sprintf("filesystems.disks.%s.eager", disk)); value != nil {
+		var err error
+		if eager, err = cast.ToStringSliceE(value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid eager transformations for disk %s: %w", disk, err))
+		}
+	}
+	for _, transformation := range eager {
+		if err := validTransformation(transformation); err != nil {
+			errs = append(errs, fmt.Errorf("invalid eager transformation %q for disk %s: %w", transformation, disk, err))
+		}
+	}
+
+	folders, _ := config.Get(fmt.Sprintf("filesystems.disks.%s.upload.folders", disk)).(map[string]any)
+	for _, folder := range sortedKeys(folders) {
+		policy, err := cast.ToStringMapE(folders[folder])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid upload policy for folder %s of disk %s: %w", folder, disk, err))
+			continue
+		}
+		for _, transformation := range cast.ToStringSlice(policy["eager"]) {
+			if err := validTransformation(transformation); err != nil {
+				errs = append(errs, fmt.Errorf("invalid eager transformation %q for folder %s of disk %s: %w", transformation, folder, disk, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckDisk validates the config of a disk, and pings the API with its credentials when ping is true.
+// The timeout bounds the ping, which is given defaultPingTimeout when it's 0.
+func CheckDisk(ctx context.Context, config config.Config, disk string, ping bool, timeout time.Duration) error {
+	if err := ValidateDisk(config, disk); err != nil {
+		return err
+	}
+	if !ping {
+		return nil
+	}
+
+	driver, err := NewCloudinary(ctx, config, disk)
+	if err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		timeout = defaultPingTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := driver.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping of disk %s failed: %w", disk, err)
+	}
+	return nil
+}
+
+// checkedDisks returns the disks that configure a check, they are checked when the application boots
+// if boot is true:
+//
+//	"check": map[string]any{
+//		"boot":    true,
+//		"ping":    true,
+//		"timeout": 5 * time.Second,
+//	},
+func checkedDisks(config config.Config) []string {
+	disks, _ := config.Get("filesystems.disks").(map[string]any)
+	var checked []string
+	for _, disk := range sortedKeys(disks) {
+		options, _ := disks[disk].(map[string]any)
+		if _, ok := options["check"]; ok {
+			checked = append(checked, disk)
+		}
+	}
+	return checked
+}
+
+// checkDisks checks the disks that ask for it when the application boots.
+func checkDisks(config config.Config) {
+	for _, disk := range checkedDisks(config) {
+		if !config.GetBool(fmt.Sprintf("filesystems.disks.%s.check.boot", disk)) {
+			continue
+		}
+		ping := config.GetBool(fmt.Sprintf("filesystems.disks.%s.check.ping", disk))
+		timeout := config.GetDuration(fmt.Sprintf("filesystems.disks.%s.check.timeout", disk), defaultPingTimeout)
+		if err := CheckDisk(context.Background(), config, disk, ping, timeout); err != nil {
+			logBootError("check disk "+disk, err)
+		}
+	}
+}
+
+// validTransformation checks the syntax of a transformation, e.g. "c_fill,w_300,h_300/e_grayscale".
+func validTransformation(transformation string) error {
+	if transformation == "" {
+		return errors.New("empty transformation")
+	}
+	for _, component := range strings.Split(transformation, "/") {
+		for _, parameter := range strings.Split(component, ",") {
+			if !transformationParameter.MatchString(parameter) {
+				return fmt.Errorf("invalid parameter %q", parameter)
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
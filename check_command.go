@@ -0,0 +1,95 @@
+package cloudinary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+)
+
+// CheckCommand checks the config of the given disks, or of the disks that configure a check, and
+// optionally pings the API with their credentials.
+type CheckCommand struct {
+	config config.Config
+}
+
+func NewCheckCommand(config config.Config) *CheckCommand {
+	return &CheckCommand{
+		config: config,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *CheckCommand) Signature() string {
+	return "cloudinary:check"
+}
+
+// Description The console command description.
+func (r *CheckCommand) Description() string {
+	return "Check the config and the credentials of the Cloudinary disks"
+}
+
+// Extend The console command extend.
+func (r *CheckCommand) Extend() command.Extend {
+	return command.Extend{
+		Category:  "cloudinary",
+		ArgsUsage: "[disk...]",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:  "ping",
+				Usage: "Ping the API with the credentials of the disks",
+			},
+			&command.StringFlag{
+				Name:  "timeout",
+				Usage: "Timeout of the ping, e.g. 5s",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command, it fails when a disk isn't valid.
+func (r *CheckCommand) Handle(ctx console.Context) error {
+	disks := ctx.Arguments()
+	if len(disks) == 0 {
+		disks = checkedDisks(r.config)
+	}
+	if len(disks) == 0 {
+		ctx.Error("No disk to check, pass the disks or add a check option to them")
+		return errors.New("no disk to check")
+	}
+
+	var timeout time.Duration
+	if value := ctx.Option("timeout"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			ctx.Error(fmt.Sprintf("Invalid timeout: %s", value))
+			return fmt.Errorf("invalid timeout: %s", value)
+		}
+		timeout = parsed
+	}
+
+	var failed []string
+	for _, disk := range disks {
+		ping := ctx.OptionBool("ping") || r.config.GetBool(fmt.Sprintf("filesystems.disks.%s.check.ping", disk))
+		diskTimeout := timeout
+		if diskTimeout == 0 {
+			diskTimeout = r.config.GetDuration(fmt.Sprintf("filesystems.disks.%s.check.timeout", disk), defaultPingTimeout)
+		}
+		if err := CheckDisk(context.Background(), r.config, disk, ping, diskTimeout); err != nil {
+			ctx.Error(fmt.Sprintf("Disk %s: %v", disk, err))
+			failed = append(failed, disk)
+			continue
+		}
+		ctx.Success(fmt.Sprintf("Disk %s is valid", disk))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("invalid disks: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
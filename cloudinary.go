@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	nethttp "net/http"
 	"os"
 	"strings"
 	"time"
@@ -13,54 +14,86 @@ import (
 	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
-	"github.com/gookit/color"
 	"github.com/goravel/framework/contracts/config"
 	"github.com/goravel/framework/contracts/filesystem"
+	"github.com/goravel/framework/contracts/log"
 	"github.com/goravel/framework/http"
 	"github.com/goravel/framework/support/str"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var assetTypes = []api.AssetType{api.Image, api.Video, api.File}
+
 type Cloudinary struct {
 	ctx      context.Context
 	config   config.Config
 	instance *cloudinary.Cloudinary
 	disk     string
+	// root is the folder all the paths of the disk are relative to.
+	root string
+	// delivery overrides the delivery type of the disk.
+	delivery string
+	// tracerProvider overrides the global tracer provider.
+	tracerProvider trace.TracerProvider
+	// log overrides the log facade of the application.
+	log log.Writer
+	// debug logs every operation and API call.
+	debug bool
+	// metrics receives the measures of the operations.
+	metrics Metrics
+	// eager overrides the eager transformations of the uploads.
+	eager *eagerUpload
 }
 
 func NewCloudinary(ctx context.Context, config config.Config, disk string) (*Cloudinary, error) {
-	cloudName := config.GetString(fmt.Sprintf("filesystems.disks.%s.cloud", disk))
-	apiKey := config.GetString(fmt.Sprintf("filesystems.disks.%s.key", disk))
-	apiSecret := config.GetString(fmt.Sprintf("filesystems.disks.%s.secret", disk))
-	if apiSecret == "" || apiKey == "" || cloudName == "" {
-		return nil, fmt.Errorf("cloudinary config not found for disk %s", disk)
+	configuration, err := configuration(config, disk)
+	if err != nil {
+		return nil, err
 	}
-	client, err := cloudinary.NewFromParams(cloudName, apiKey, apiSecret)
+	client, err := cloudinary.NewFromConfiguration(*configuration)
 	if err != nil {
-		color.Redln("[Cloudinary] init disk error: ", err)
 		return nil, err
 	}
+	metrics, _ := config.Get(fmt.Sprintf("filesystems.disks.%s.metrics", disk)).(Metrics)
+	policy := retryPolicy(config, disk)
+	instrumented := &loggingTransport{base: &metricsTransport{base: &tracingTransport{disk: disk, base: nethttp.DefaultTransport}}}
+	client.Admin.Client.Transport = &retryTransport{policy: policy, base: &rateLimitTransport{
+		state:     rateLimits.get(client.Config.Cloud.CloudName, client.Config.Cloud.APIKey),
+		threshold: config.GetInt(fmt.Sprintf("filesystems.disks.%s.rate_limit.threshold", disk), 0),
+		base:      instrumented,
+	}}
+	client.Upload.Client.Transport = &retryTransport{policy: policy, base: instrumented}
 	return &Cloudinary{
 		ctx:      ctx,
 		config:   config,
 		instance: client,
 		disk:     disk,
+		root:     validPath(config.GetString(fmt.Sprintf("filesystems.disks.%s.root", disk))),
+		debug:    config.GetBool(fmt.Sprintf("filesystems.disks.%s.debug", disk), false),
+		metrics:  metrics,
 	}, nil
 }
 
 // AllDirectories returns all the directories within a given directory and all its subdirectories.
-func (r *Cloudinary) AllDirectories(path string) ([]string, error) {
+func (r *Cloudinary) AllDirectories(path string) (_ []string, err error) {
+	r, op := r.startOperation("AllDirectories", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
+
 	var result []string
-	folders, err := r.instance.Admin.SubFolders(r.ctx, admin.SubFoldersParams{
-		Folder: validPath(path),
-	})
+	folders, err := r.subFolders(path)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, folder := range folders.Folders {
-		result = append(result, folder.Path)
+	for _, folder := range folders {
+		if err := r.ctx.Err(); err != nil {
+			return nil, err
+		}
+		folderPath, _ := relativePath(r.root, folder.Path)
+		result = append(result, folderPath)
 		// Recursively call to get directories in the subdirectory
-		subdirs, err := r.AllDirectories(folder.Path)
+		subdirs, err := r.AllDirectories(folderPath)
 		if err != nil {
 			return nil, err
 		}
@@ -71,15 +104,20 @@ func (r *Cloudinary) AllDirectories(path string) ([]string, error) {
 }
 
 // AllFiles returns all the files from the given directory including all its subdirectories.
-func (r *Cloudinary) AllFiles(path string) ([]string, error) {
+func (r *Cloudinary) AllFiles(path string) (_ []string, err error) {
+	r, op := r.startOperation("AllFiles", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
+
 	var result []string
-	assetTypes := []api.AssetType{api.Image, api.Video, api.File}
 	for _, assetType := range assetTypes {
 		nextCursor := ""
 		for {
+			if err := r.ctx.Err(); err != nil {
+				return nil, err
+			}
 			response, err := r.instance.Admin.Assets(r.ctx, admin.AssetsParams{
-				Prefix:       validPath(path),
-				DeliveryType: "upload",
+				Prefix:       rootPath(r.root, path),
+				DeliveryType: r.deliveryType(),
 				AssetType:    assetType,
 				MaxResults:   500,
 				NextCursor:   nextCursor,
@@ -87,9 +125,14 @@ func (r *Cloudinary) AllFiles(path string) ([]string, error) {
 			if err != nil {
 				return nil, err
 			}
+			if err := r.apiError("list files", response.Error); err != nil {
+				return nil, err
+			}
 
 			for _, folder := range response.Assets {
-				result = append(result, folder.PublicID)
+				if file, ok := relativePath(r.root, folder.PublicID); ok {
+					result = append(result, file)
+				}
 			}
 
 			nextCursor = response.NextCursor
@@ -102,93 +145,140 @@ func (r *Cloudinary) AllFiles(path string) ([]string, error) {
 }
 
 // Copy copies a file to a new location.
-func (r *Cloudinary) Copy(source, destination string) error {
-	result, err := r.instance.Upload.Upload(r.ctx, r.Url(source), uploader.UploadParams{
-		PublicID:     destination,
+func (r *Cloudinary) Copy(source, destination string) (err error) {
+	r, op := r.startOperation("Copy", attribute.String("cloudinary.path", source), attribute.String("cloudinary.destination", destination))
+	defer func() { op.end(err) }()
+
+	if err := checkFile(destination); err != nil {
+		return err
+	}
+	url, err := r.url(source)
+	if err != nil {
+		return err
+	}
+	result, err := r.instance.Upload.Upload(r.ctx, url, uploader.UploadParams{
+		PublicID:     rootPath(r.root, destination),
+		Type:         api.DeliveryType(r.deliveryType()),
 		ResourceType: "auto",
 	})
 	if err != nil {
 		return err
 	}
-	if result.Error.Message != "" {
-		return fmt.Errorf("copy file error: %#v", result.Error)
-	}
-	return nil
+	return r.apiError("copy file", result.Error)
 }
 
 // Delete deletes a file.
-func (r *Cloudinary) Delete(file ...string) error {
+func (r *Cloudinary) Delete(file ...string) (err error) {
+	r, op := r.startOperation("Delete", attribute.StringSlice("cloudinary.paths", file))
+	defer func() { op.end(err) }()
+
 	for _, f := range file {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
 		asset, err := r.getAsset(f)
 		if err != nil {
 			return err
 		}
 		result, err := r.instance.Upload.Destroy(r.ctx, uploader.DestroyParams{
 			PublicID:     asset.PublicID,
+			Type:         asset.Type,
 			Invalidate:   api.Bool(true),
 			ResourceType: asset.ResourceType,
 		})
 		if err != nil {
 			return err
 		}
+		if err := r.apiError("delete file", result.Error); err != nil {
+			return err
+		}
+		if result.Result == "not found" {
+			return fmt.Errorf("%w: %s", ErrNotFound, f)
+		}
 		if result.Result != "ok" {
-			return fmt.Errorf("delete file error: %+v", result.Error)
+			return &APIError{Action: "delete file", Message: result.Result}
 		}
 	}
 	return nil
 }
 
 // DeleteDirectory deletes a directory.
-func (r *Cloudinary) DeleteDirectory(directory string) error {
-	assetTypes := []api.AssetType{api.Image, api.Video, api.File}
+func (r *Cloudinary) DeleteDirectory(directory string) (err error) {
+	r, op := r.startOperation("DeleteDirectory", attribute.String("cloudinary.path", directory))
+	defer func() { op.end(err) }()
+
 	for _, assetType := range assetTypes {
-		_, err := r.instance.Admin.DeleteAssetsByPrefix(r.ctx, admin.DeleteAssetsByPrefixParams{
-			Prefix:    []string{validPath(directory)},
-			AssetType: assetType,
+		result, err := r.instance.Admin.DeleteAssetsByPrefix(r.ctx, admin.DeleteAssetsByPrefixParams{
+			Prefix:       []string{rootPath(r.root, directory)},
+			AssetType:    assetType,
+			DeliveryType: api.DeliveryType(r.deliveryType()),
 		})
 		if err != nil {
 			return err
 		}
+		if err := r.apiError("delete directory", result.Error); err != nil {
+			return err
+		}
 	}
 
-	_, err := r.instance.Admin.DeleteFolder(r.ctx, admin.DeleteFolderParams{
-		Folder: directory,
+	result, err := r.instance.Admin.DeleteFolder(r.ctx, admin.DeleteFolderParams{
+		Folder: rootPath(r.root, directory),
 	})
 	if err != nil {
 		return err
 	}
+	// Deleting a directory that doesn't exist succeeds, like with the other drivers.
+	if err := r.apiError("delete directory", result.Error); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
 	return nil
 }
 
 // Directories return all the directories within a given directory.
-func (r *Cloudinary) Directories(path string) ([]string, error) {
-	folders, err := r.instance.Admin.SubFolders(r.ctx, admin.SubFoldersParams{
-		Folder: validPath(path),
-	})
+func (r *Cloudinary) Directories(path string) (_ []string, err error) {
+	r, op := r.startOperation("Directories", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
+
+	folders, err := r.subFolders(path)
 	if err != nil {
 		return nil, err
 	}
 	var result []string
-	for _, folder := range folders.Folders {
-		result = append(result, folder.Path)
+	for _, folder := range folders {
+		folderPath, _ := relativePath(r.root, folder.Path)
+		result = append(result, folderPath)
 	}
 	return result, nil
 }
 
-// Exists checks if a file exists in the Cloudinary storage.
+// Check checks if a file, or a directory when the path ends with a slash, exists in the Cloudinary
+// storage. Unlike Exists, it returns the errors that prevent knowing it, e.g. ErrUnauthorized or a
+// network error, instead of reporting the file as missing.
+func (r *Cloudinary) Check(file string) (_ bool, err error) {
+	r, op := r.startOperation("Check", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	return r.check(file)
+}
+
+// Exists checks if a file exists in the Cloudinary storage, it's false when that can't be checked.
 func (r *Cloudinary) Exists(file string) bool {
-	if strings.HasSuffix(file, "/") {
-		return r.isDirectoryExist(file)
+	r, op := r.startOperation("Exists", attribute.String("cloudinary.path", file))
+	exists, err := r.check(file)
+	op.end(err)
+	if err != nil && op.logger != nil {
+		op.logger.With(op.fields()).Warningf("[Cloudinary] check of %s error: %+v", file, err)
 	}
-
-	_, err := r.getAsset(file)
-	return err == nil
+	return exists
 }
 
 // Files returns all the files from the given directory.
-func (r *Cloudinary) Files(path string) ([]string, error) {
+func (r *Cloudinary) Files(path string) (_ []string, err error) {
+	r, op := r.startOperation("Files", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
+
 	folders, err := r.instance.Admin.Search(r.ctx, search.Query{
-		Expression: fmt.Sprintf("folder:%s", validPath(path)),
+		Expression: fmt.Sprintf("folder:%s AND type:%s", rootPath(r.root, path), r.deliveryType()),
 		SortBy: []search.SortByField{
 			{"public_id": search.Ascending},
 		},
@@ -196,9 +286,13 @@ func (r *Cloudinary) Files(path string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := r.apiError("list files", folders.Error); err != nil {
+		return nil, err
+	}
 	var result []string
 	for _, folder := range folders.Assets {
-		result = append(result, folder.PublicID)
+		file, _ := relativePath(r.root, folder.PublicID)
+		result = append(result, file)
 	}
 	return result, nil
 }
@@ -213,16 +307,27 @@ func (r *Cloudinary) Get(file string) (string, error) {
 }
 
 // GetBytes returns the byte of a file.
-func (r *Cloudinary) GetBytes(file string) ([]byte, error) {
-	rawContent, err := GetRawContent(r.Url(file))
+func (r *Cloudinary) GetBytes(file string) (_ []byte, err error) {
+	r, op := r.startOperation("GetBytes", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	url, err := r.url(file)
 	if err != nil {
 		return nil, err
 	}
+	rawContent, err := getRawContent(r.ctx, r.httpClient(), url)
+	if err != nil {
+		return nil, err
+	}
+	r.setBytes(DirectionDownload, len(rawContent))
 	return rawContent, nil
 }
 
 // LastModified returns the last modified time of a file.
-func (r *Cloudinary) LastModified(file string) (time.Time, error) {
+func (r *Cloudinary) LastModified(file string) (_ time.Time, err error) {
+	r, op := r.startOperation("LastModified", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
 	resource, err := r.getAsset(file)
 	if err != nil {
 		return time.Time{}, err
@@ -231,21 +336,30 @@ func (r *Cloudinary) LastModified(file string) (time.Time, error) {
 }
 
 // MakeDirectory creates a directory.
-func (r *Cloudinary) MakeDirectory(directory string) error {
+func (r *Cloudinary) MakeDirectory(directory string) (err error) {
+	r, op := r.startOperation("MakeDirectory", attribute.String("cloudinary.path", directory))
+	defer func() { op.end(err) }()
+
 	result, err := r.instance.Admin.CreateFolder(r.ctx, admin.CreateFolderParams{
-		Folder: validPath(directory),
+		Folder: rootPath(r.root, directory),
 	})
 	if err != nil {
 		return err
 	}
+	if err := r.apiError("make directory", result.Error); err != nil {
+		return err
+	}
 	if !result.Success {
-		return fmt.Errorf("make directory error: %+v", result.Error)
+		return &APIError{Action: "make directory", Message: "folder not created"}
 	}
 	return nil
 }
 
 // MimeType returns the mime-type of a file.
-func (r *Cloudinary) MimeType(file string) (string, error) {
+func (r *Cloudinary) MimeType(file string) (_ string, err error) {
+	r, op := r.startOperation("MimeType", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
 	resource, err := r.getAsset(file)
 	if err != nil {
 		return "", err
@@ -260,38 +374,54 @@ func (r *Cloudinary) MimeType(file string) (string, error) {
 	return resource.ResourceType + "/" + format, nil
 }
 
-// Missing checks if a file is missing.
+// Missing checks if a file is missing, it's false when that can't be checked.
 func (r *Cloudinary) Missing(file string) bool {
-	return !r.Exists(file)
+	r, op := r.startOperation("Missing", attribute.String("cloudinary.path", file))
+	exists, err := r.check(file)
+	op.end(err)
+	if err != nil && op.logger != nil {
+		op.logger.With(op.fields()).Warningf("[Cloudinary] check of %s error: %+v", file, err)
+	}
+	return err == nil && !exists
 }
 
 // Move moves a file to a new location.
-func (r *Cloudinary) Move(source, destination string) error {
+func (r *Cloudinary) Move(source, destination string) (err error) {
+	r, op := r.startOperation("Move", attribute.String("cloudinary.path", source), attribute.String("cloudinary.destination", destination))
+	defer func() { op.end(err) }()
+
+	if err := checkFile(destination); err != nil {
+		return err
+	}
 	asset, err := r.getAsset(source)
 	if err != nil {
 		return err
 	}
 	rename, err := r.instance.Upload.Rename(r.ctx, uploader.RenameParams{
 		FromPublicID: asset.PublicID,
-		ToPublicID:   destination,
+		ToPublicID:   rootPath(r.root, destination),
+		Type:         asset.Type,
 		ResourceType: asset.ResourceType,
 	})
 	if err != nil {
 		return err
 	}
-	if rename.Error != nil {
-		return fmt.Errorf("move file error: %#v", rename.Error)
-	}
-	return nil
+	return r.apiError("move file", errorResp(rename.Error))
 }
 
 // Path returns the full path for a file.
 func (r *Cloudinary) Path(file string) string {
-	return validPath(file)
+	return rootPath(r.root, file)
 }
 
 // Put stores a new file on the disk.
-func (r *Cloudinary) Put(file, content string) error {
+func (r *Cloudinary) Put(file, content string) (err error) {
+	r, op := r.startOperation("Put", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	if err := checkFile(file); err != nil {
+		return err
+	}
 	// If the file is created in a folder directly, we can't check if the folder exists.
 	// So we need to create the top folder first.
 	if err := r.makeDirectories(file); err != nil {
@@ -299,12 +429,12 @@ func (r *Cloudinary) Put(file, content string) error {
 	}
 
 	tempFile, err := r.tempFile(content)
-	defer os.Remove(tempFile.Name())
 	if err != nil {
 		return err
 	}
-	_, err = r.instance.Upload.Upload(r.ctx, tempFile.Name(), uploader.UploadParams{
-		PublicID:       file,
+	defer os.Remove(tempFile.Name())
+	_, err = r.upload(tempFile.Name(), uploader.UploadParams{
+		PublicID:       rootPath(r.root, file),
 		UseFilename:    api.Bool(true),
 		UniqueFilename: api.Bool(false),
 		ResourceType:   "auto",
@@ -313,46 +443,28 @@ func (r *Cloudinary) Put(file, content string) error {
 }
 
 // PutFile stores a new file on the disk.
-func (r *Cloudinary) PutFile(path string, source filesystem.File) (string, error) {
-	// If the file is created in a folder directly, we can't check if the folder exists.
-	// So we need to create the top folder first.
-	if err := r.makeDirectories(str.Of(path).Finish("/").String()); err != nil {
-		return "", err
-	}
+func (r *Cloudinary) PutFile(path string, source filesystem.File) (_ string, err error) {
+	r, op := r.startOperation("PutFile", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
 
-	uploadResult, err := r.instance.Upload.Upload(r.ctx, source.File(), uploader.UploadParams{
-		Folder:         validPath(path),
-		UseFilename:    api.Bool(true),
-		UniqueFilename: api.Bool(false),
-	})
-	if err != nil {
-		return "", err
-	}
-	return uploadResult.PublicID, nil
+	file, _, err := r.putFile(path, source, "", r.duplicatePolicy())
+	return file, err
 }
 
 // PutFileAs stores a new file on the disk.
-func (r *Cloudinary) PutFileAs(path string, source filesystem.File, name string) (string, error) {
-	// If the file is created in a folder directly, we can't check if the folder exists.
-	// So we need to create the top folder first.
-	if err := r.makeDirectories(str.Of(path).Finish("/").String()); err != nil {
-		return "", err
-	}
+func (r *Cloudinary) PutFileAs(path string, source filesystem.File, name string) (_ string, err error) {
+	r, op := r.startOperation("PutFileAs", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
 
-	uploadResult, err := r.instance.Upload.Upload(r.ctx, source.File(), uploader.UploadParams{
-		Folder:         validPath(path),
-		PublicID:       name,
-		UseFilename:    api.Bool(true),
-		UniqueFilename: api.Bool(false),
-	})
-	if err != nil {
-		return "", err
-	}
-	return uploadResult.PublicID, nil
+	file, _, err := r.putFile(path, source, name, r.duplicatePolicy())
+	return file, err
 }
 
 // Size returns the file size of a given file.
-func (r *Cloudinary) Size(file string) (int64, error) {
+func (r *Cloudinary) Size(file string) (_ int64, err error) {
+	r, op := r.startOperation("Size", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
 	resource, err := r.getAsset(file)
 	if err != nil {
 		return 0, err
@@ -365,49 +477,161 @@ func (r *Cloudinary) TemporaryUrl(file string, time time.Time) (string, error) {
 	return "", errors.New("cloudinary doesn't support temporary url")
 }
 
-// WithContext sets the context for the driver.
+// WithContext returns a copy of the driver that uses the context for its API calls. The copy shares
+// the client of the driver, so it's cheap enough to be made for every request.
 func (r *Cloudinary) WithContext(ctx context.Context) filesystem.Driver {
+	return r.WithContextDriver(ctx)
+}
+
+// WithContextDriver is WithContext returning the Driver, so the Cloudinary operations stay available
+// without a type assertion.
+func (r *Cloudinary) WithContextDriver(ctx context.Context) Driver {
 	if httpCtx, ok := ctx.(http.Context); ok {
 		ctx = httpCtx.Context()
 	}
-
-	driver, err := NewCloudinary(ctx, r.config, r.disk)
-	if err != nil {
-		color.Redf("[Cloudinary] init disk error: %+v\n", err)
-		return nil
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	return driver
+
+	return r.withContext(ctx)
+}
+
+// WithDeliveryType returns a copy of the driver that uses the delivery type instead of the one of the disk,
+// e.g. "upload", "private" or "authenticated".
+func (r *Cloudinary) WithDeliveryType(deliveryType string) Driver {
+	driver := *r
+	driver.delivery = deliveryType
+	return &driver
 }
 
 // Url returns the url for a file.
 func (r *Cloudinary) Url(file string) string {
+	r, op := r.startOperation("Url", attribute.String("cloudinary.path", file))
+	url, err := r.url(file)
+	op.end(err)
+	if err != nil && op.logger != nil {
+		op.logger.With(op.fields()).Warningf("[Cloudinary] url of %s error: %+v", file, err)
+	}
+	return url
+}
+
+func (r *Cloudinary) url(file string) (string, error) {
 	asset, err := r.getAsset(file)
 	if err != nil {
-		return ""
+		return "", err
+	}
+	return r.assetUrl(asset)
+}
+
+// assetUrl returns the url that downloads the original of an asset.
+func (r *Cloudinary) assetUrl(asset *uploader.ExplicitResult) (string, error) {
+	// The originals of private files are only delivered through the download API.
+	if asset.Type == string(api.Private) {
+		return r.instance.Upload.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+			PublicID:     asset.PublicID,
+			Format:       asset.Format,
+			DeliveryType: asset.Type,
+			ResourceType: api.AssetType(asset.ResourceType),
+		})
+	}
+
+	return r.deliveryUrl(asset)
+}
+
+// deliveryUrl builds the url of an asset from the disk configuration instead of using the one returned by
+// the API, so that the secure_distribution, cname, private_cdn and cdn_subdomain options are respected.
+// Only the host changes, the signature of the path stays valid.
+func (r *Cloudinary) deliveryUrl(asset *uploader.ExplicitResult) (string, error) {
+	publicID := asset.PublicID
+	// Only the public IDs of raw files contain the extension.
+	if asset.ResourceType != string(api.File) && asset.Format != "" {
+		publicID += "." + asset.Format
+	}
+	media, err := r.instance.Media(publicID)
+	if err != nil {
+		return "", err
 	}
-	return asset.SecureURL
+	media.AssetType = api.AssetType(asset.ResourceType)
+	media.DeliveryType = api.DeliveryType(asset.Type)
+	media.Version = asset.Version
+	media.Config.URL.SignURL = media.Config.URL.SignURL || media.DeliveryType != api.Upload
+	media.Config.URL.Analytics = false
+	return media.String()
 }
 
 func (r *Cloudinary) getAsset(path string) (*uploader.ExplicitResult, error) {
+	if err := checkFile(path); err != nil {
+		return nil, err
+	}
 	// TODO: Search if there is a better way to get asset info
-	assetTypes := []api.AssetType{api.Image, api.Video, api.File}
 	for _, assetType := range assetTypes {
 		explicit, err := r.instance.Upload.Explicit(r.ctx, uploader.ExplicitParams{
-			PublicID:     path,
-			Type:         "upload",
+			PublicID:     rootPath(r.root, path),
+			Type:         api.DeliveryType(r.deliveryType()),
 			ResourceType: string(assetType),
 		})
 		if err != nil {
 			return nil, err
 		}
-		if explicit.Error.Message == "" {
+		err = r.apiError("get file", explicit.Error)
+		if err == nil {
+			r.setResourceType(explicit.ResourceType)
 			return explicit, nil
 		}
+		// Only a file missing with this resource type is worth looking for with the next one.
+		if status := err.(*APIError).Status; status != 0 && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
 	}
-	return nil, errors.New("file not found")
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
 }
 
-func (r *Cloudinary) isDirectoryExist(path string) bool {
+// deliveryType returns the delivery type of the driver, set by the delivery_type disk option
+// or WithDeliveryType, "upload" by default.
+func (r *Cloudinary) deliveryType() string {
+	if r.delivery != "" {
+		return r.delivery
+	}
+	if deliveryType := r.config.GetString(fmt.Sprintf("filesystems.disks.%s.delivery_type", r.disk)); deliveryType != "" {
+		return deliveryType
+	}
+	return string(api.Upload)
+}
+
+// subFolders returns the folders within a directory, none when it doesn't exist.
+func (r *Cloudinary) subFolders(path string) ([]admin.FolderResult, error) {
+	folders, err := r.instance.Admin.SubFolders(r.ctx, admin.SubFoldersParams{
+		Folder: rootPath(r.root, path),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("list directories", folders.Error); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return folders.Folders, nil
+}
+
+func (r *Cloudinary) check(file string) (bool, error) {
+	if strings.HasSuffix(file, "/") {
+		return r.isDirectoryExist(file)
+	}
+
+	_, err := r.getAsset(file)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *Cloudinary) isDirectoryExist(path string) (bool, error) {
+	if err := checkPath(path); err != nil {
+		return false, err
+	}
+	path = rootPath(r.root, path)
 	pathNoSlash := strings.TrimSuffix(path, "/")
 	paths := str.Of(path).RTrim("/").Split("/")
 	searchPath := "/"
@@ -420,19 +644,31 @@ func (r *Cloudinary) isDirectoryExist(path string) bool {
 		MaxResults: 100000,
 	})
 	if err != nil {
-		return false
+		return false, err
+	}
+	if err := r.apiError("list directories", folders.Error); err != nil {
+		// The parent directory is missing too.
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
 	}
 	for _, folder := range folders.Folders {
 		if folder.Path == pathNoSlash {
-			return true
+			return true, nil
 		}
 	}
-	return false
+	return false, nil
 }
 
 func (r *Cloudinary) makeDirectories(path string) error {
 	folders := strings.Split(path, "/")
-	for i := 1; i < len(folders); i++ {
+	// The root folder, which is an empty path, is created first.
+	start := 1
+	if r.root != "" {
+		start = 0
+	}
+	for i := start; i < len(folders); i++ {
 		folder := strings.Join(folders[:i], "/")
 		if err := r.MakeDirectory(folder); err != nil {
 			return err
@@ -1,15 +1,20 @@
 package cloudinary
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"io"
 	"mime"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"github.com/gookit/color"
 	contractsfilesystem "github.com/goravel/framework/contracts/filesystem"
 	mocksconfig "github.com/goravel/framework/mocks/config"
@@ -29,6 +34,11 @@ func TestStorage(t *testing.T) {
 	mockConfig.On("GetString", "filesystems.disks.cloudinary.key").Return(os.Getenv("CLOUDINARY_ACCESS_KEY_ID"))
 	mockConfig.On("GetString", "filesystems.disks.cloudinary.secret").Return(os.Getenv("CLOUDINARY_ACCESS_KEY_SECRET"))
 	mockConfig.On("GetString", "filesystems.disks.cloudinary.cloud").Return(os.Getenv("CLOUDINARY_CLOUD"))
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.eager_notification_url").Return("")
+	mockConfig.On("Get", "filesystems.disks.cloudinary.eager").Return(nil)
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.duplicates").Return("")
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.delivery_type").Return("")
+	mockDiskConfig(mockConfig, "cloudinary", nil)
 
 	driver, err := NewCloudinary(context.Background(), mockConfig, "cloudinary")
 	assert.NotNil(t, driver)
@@ -96,6 +106,45 @@ func TestStorage(t *testing.T) {
 				assert.Nil(t, driver.DeleteDirectory("AllFiles"))
 			},
 		},
+		{
+			name: "Archive",
+			setup: func() {
+				assert.Nil(t, driver.Put("Archive/1.txt", "Goravel"))
+				assert.Nil(t, driver.Put("Archive/a/2.txt", "Goravel"))
+				url, err := driver.Archive(ArchiveOptions{Prefixes: []string{"Archive"}})
+				assert.Nil(t, err)
+				assert.NotEmpty(t, url)
+
+				for _, fallback := range []bool{false, true} {
+					var buffer bytes.Buffer
+					options := ArchiveOptions{Prefixes: []string{"Archive"}, FlattenFolders: true}
+					if fallback {
+						assert.Nil(t, driver.localArchive(&buffer, options))
+					} else {
+						assert.Nil(t, driver.ArchiveTo(&buffer, options))
+					}
+					reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+					assert.Nil(t, err)
+					var names []string
+					for _, file := range reader.File {
+						names = append(names, file.Name)
+					}
+					assert.ElementsMatch(t, []string{"1.txt", "2.txt"}, names)
+				}
+				assert.Nil(t, driver.DeleteDirectory("Archive"))
+			},
+		},
+		{
+			name: "Context",
+			setup: func() {
+				assert.Nil(t, driver.Put("Context/1.txt", "Goravel"))
+				assert.Nil(t, driver.SetContextMetadata("Context/1.txt", map[string]string{"alt": "Goravel", "caption": "a=b"}))
+				values, err := driver.GetContextMetadata("Context/1.txt")
+				assert.Nil(t, err)
+				assert.Equal(t, map[string]string{"alt": "Goravel", "caption": "a=b"}, values)
+				assert.Nil(t, driver.DeleteDirectory("Context"))
+			},
+		},
 		{
 			name: "Copy",
 			setup: func() {
@@ -359,6 +408,30 @@ func TestStorage(t *testing.T) {
 				assert.Nil(t, driver.DeleteDirectory("Size"))
 			},
 		},
+		{
+			name: "Tags",
+			setup: func() {
+				assert.Nil(t, driver.Put("Tags/1.txt", "Goravel"))
+				assert.Nil(t, driver.Put("Tags/2.txt", "Goravel"))
+				assert.Nil(t, driver.AddTags("goravel-tags", "Tags/1.txt", "./Tags/2.txt"))
+				files, err := driver.FilesByTag("goravel-tags")
+				assert.Nil(t, err)
+				assert.ElementsMatch(t, []string{"Tags/1.txt", "Tags/2.txt"}, files)
+				assert.Nil(t, driver.RemoveTags("goravel-tags", "Tags/2.txt"))
+				files, err = driver.FilesByTag("goravel-tags")
+				assert.Nil(t, err)
+				assert.Equal(t, []string{"Tags/1.txt"}, files)
+				assert.Nil(t, driver.ReplaceTags("goravel-tags-new", "Tags/1.txt"))
+				files, err = driver.FilesByTag("goravel-tags")
+				assert.Nil(t, err)
+				assert.Empty(t, files)
+				assert.Nil(t, driver.ClearTags("Tags/1.txt"))
+				files, err = driver.FilesByTag("goravel-tags-new")
+				assert.Nil(t, err)
+				assert.Empty(t, files)
+				assert.Nil(t, driver.DeleteDirectory("Tags"))
+			},
+		},
 		{
 			name: "TemporaryUrl",
 			setup: func() {
@@ -395,6 +468,151 @@ func TestStorage(t *testing.T) {
 	assert.Nil(t, os.Remove("test.txt"))
 }
 
+func TestCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(body), "public_id=intro&") && strings.HasPrefix(r.URL.Path, "/v1_1/cloud/video/"):
+			_, _ = w.Write([]byte(`{"public_id":"intro","resource_type":"video","type":"upload"}`))
+		case r.URL.Path == "/v1_1/cloud/folders/videos":
+			_, _ = w.Write([]byte(`{"folders":[{"name":"intro","path":"videos/intro"}]}`))
+		case strings.Contains(string(body), "public_id=private&"), r.URL.Path == "/v1_1/cloud/folders/private":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid Signature"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"Resource not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	driver := newServerDriver(t, server.URL, nil)
+
+	tests := []struct {
+		path    string
+		exists  bool
+		missing bool
+		expect  error
+	}{
+		{path: "intro", exists: true},
+		{path: "outro", missing: true},
+		{path: "private", expect: ErrUnauthorized},
+		{path: "videos/intro/", exists: true},
+		{path: "videos/outro/", missing: true},
+		{path: "audios/intro/", missing: true},
+		{path: "private/intro/", expect: ErrUnauthorized},
+		{path: "intro?", expect: ErrInvalidPath},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			exists, err := driver.Check(test.path)
+			assert.Equal(t, test.exists, exists)
+			if test.expect != nil {
+				assert.ErrorIs(t, err, test.expect)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			// A file that can't be checked is neither existing nor missing.
+			assert.Equal(t, test.exists, driver.Exists(test.path))
+			assert.Equal(t, test.missing, driver.Missing(test.path))
+		})
+	}
+}
+
+func TestDeliveryUrl(t *testing.T) {
+	image := &uploader.ExplicitResult{UploadResult: uploader.UploadResult{PublicID: "avatars/goravel", Format: "jpg", ResourceType: "image", Type: "upload", Version: 1700000000}}
+	tests := []struct {
+		name    string
+		options map[string]any
+		asset   *uploader.ExplicitResult
+		expect  string
+	}{
+		{
+			name:   "default",
+			asset:  image,
+			expect: `^https://res\.cloudinary\.com/cloud/image/upload/v1700000000/avatars/goravel\.jpg$`,
+		},
+		{
+			name:    "secure distribution",
+			options: map[string]any{"secure_distribution": "media.example.com", "private_cdn": true},
+			asset:   image,
+			expect:  `^https://media\.example\.com/image/upload/v1700000000/avatars/goravel\.jpg$`,
+		},
+		{
+			name:    "private cdn",
+			options: map[string]any{"private_cdn": true},
+			asset:   image,
+			expect:  `^https://cloud-res\.cloudinary\.com/image/upload/v1700000000/avatars/goravel\.jpg$`,
+		},
+		{
+			name:    "cdn subdomain",
+			options: map[string]any{"cdn_subdomain": true},
+			asset:   image,
+			expect:  `^https://res-[1-5]\.cloudinary\.com/cloud/image/upload/v1700000000/avatars/goravel\.jpg$`,
+		},
+		{
+			name:    "cname",
+			options: map[string]any{"cname": "static.example.com", "secure": false},
+			asset:   image,
+			expect:  `^http://static\.example\.com/cloud/image/upload/v1700000000/avatars/goravel\.jpg$`,
+		},
+		{
+			name:   "raw",
+			asset:  &uploader.ExplicitResult{UploadResult: uploader.UploadResult{PublicID: "docs/goravel.pdf", ResourceType: "raw", Type: "upload", Version: 1700000000}},
+			expect: `^https://res\.cloudinary\.com/cloud/raw/upload/v1700000000/docs/goravel\.pdf$`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"}
+			for key, value := range test.options {
+				options[key] = value
+			}
+			url, err := newTestDriver(t, options).deliveryUrl(test.asset)
+			assert.Nil(t, err)
+			assert.Regexp(t, test.expect, url)
+		})
+	}
+
+	// The signature only covers the path, so it's the same whatever the host is.
+	authenticated := &uploader.ExplicitResult{UploadResult: uploader.UploadResult{PublicID: "docs/contract", Format: "png", ResourceType: "image", Type: "authenticated", Version: 1700000000}}
+	shared, err := newTestDriver(t, map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"}).deliveryUrl(authenticated)
+	assert.Nil(t, err)
+	custom, err := newTestDriver(t, map[string]any{"cloud": "cloud", "key": "key", "secret": "secret", "secure_distribution": "media.example.com", "private_cdn": true}).deliveryUrl(authenticated)
+	assert.Nil(t, err)
+	assert.Regexp(t, `^https://res\.cloudinary\.com/cloud/image/authenticated/s--[\w-]{8}--/v1700000000/docs/contract\.png$`, shared)
+	assert.Equal(t, strings.TrimPrefix(shared, "https://res.cloudinary.com/cloud"), strings.TrimPrefix(custom, "https://media.example.com"))
+}
+
+func TestWithContext(t *testing.T) {
+	mockConfig := mocksconfig.NewConfig(t)
+	mockDiskConfig(mockConfig, "cloudinary", map[string]any{"cloud": "cloud", "key": "key", "secret": "secret", "root": "goravel"})
+	driver, err := NewCloudinary(context.Background(), mockConfig, "cloudinary")
+	assert.Nil(t, err)
+	driver = driver.WithDeliveryType("private").(*Cloudinary)
+	calls := len(mockConfig.Calls)
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "request")
+	copied, ok := driver.WithContext(ctx).(*Cloudinary)
+	assert.True(t, ok)
+	assert.Equal(t, "request", copied.ctx.Value(key{}))
+	assert.Same(t, driver.instance, copied.instance)
+	assert.Equal(t, "goravel", copied.root)
+	assert.Equal(t, "private", copied.delivery)
+	// The config isn't read again.
+	assert.Len(t, mockConfig.Calls, calls)
+
+	assert.NotNil(t, driver.WithContext(nil).(*Cloudinary).ctx)
+	typed, ok := driver.WithContextDriver(ctx).(*Cloudinary)
+	assert.True(t, ok)
+	assert.Equal(t, "request", typed.ctx.Value(key{}))
+	assert.Equal(t, context.Background(), driver.ctx)
+}
+
 type File struct {
 	path string
 }
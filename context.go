@@ -0,0 +1,254 @@
+package cloudinary
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/goravel/framework/contracts/filesystem"
+)
+
+// The Context variants of the operations use the context for their API calls instead of the one of the
+// driver, so a driver can be shared by concurrent requests and jobs without calling WithContext first.
+
+// withContext returns a copy of the driver that uses the context, sharing its client.
+func (r *Cloudinary) withContext(ctx context.Context) *Cloudinary {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	driver := *r
+	driver.ctx = ctx
+	return &driver
+}
+
+// AllDirectoriesContext is AllDirectories with the context of the call.
+func (r *Cloudinary) AllDirectoriesContext(ctx context.Context, path string) ([]string, error) {
+	return r.withContext(ctx).AllDirectories(path)
+}
+
+// AllFilesContext is AllFiles with the context of the call.
+func (r *Cloudinary) AllFilesContext(ctx context.Context, path string) ([]string, error) {
+	return r.withContext(ctx).AllFiles(path)
+}
+
+// CheckContext is Check with the context of the call.
+func (r *Cloudinary) CheckContext(ctx context.Context, file string) (bool, error) {
+	return r.withContext(ctx).Check(file)
+}
+
+// CopyContext is Copy with the context of the call.
+func (r *Cloudinary) CopyContext(ctx context.Context, source, destination string) error {
+	return r.withContext(ctx).Copy(source, destination)
+}
+
+// DeleteContext is Delete with the context of the call.
+func (r *Cloudinary) DeleteContext(ctx context.Context, file ...string) error {
+	return r.withContext(ctx).Delete(file...)
+}
+
+// DeleteDirectoryContext is DeleteDirectory with the context of the call.
+func (r *Cloudinary) DeleteDirectoryContext(ctx context.Context, directory string) error {
+	return r.withContext(ctx).DeleteDirectory(directory)
+}
+
+// DirectoriesContext is Directories with the context of the call.
+func (r *Cloudinary) DirectoriesContext(ctx context.Context, path string) ([]string, error) {
+	return r.withContext(ctx).Directories(path)
+}
+
+// ExistsContext is Exists with the context of the call.
+func (r *Cloudinary) ExistsContext(ctx context.Context, file string) bool {
+	return r.withContext(ctx).Exists(file)
+}
+
+// FilesContext is Files with the context of the call.
+func (r *Cloudinary) FilesContext(ctx context.Context, path string) ([]string, error) {
+	return r.withContext(ctx).Files(path)
+}
+
+// GetContext is Get with the context of the call.
+func (r *Cloudinary) GetContext(ctx context.Context, file string) (string, error) {
+	return r.withContext(ctx).Get(file)
+}
+
+// GetBytesContext is GetBytes with the context of the call.
+func (r *Cloudinary) GetBytesContext(ctx context.Context, file string) ([]byte, error) {
+	return r.withContext(ctx).GetBytes(file)
+}
+
+// LastModifiedContext is LastModified with the context of the call.
+func (r *Cloudinary) LastModifiedContext(ctx context.Context, file string) (time.Time, error) {
+	return r.withContext(ctx).LastModified(file)
+}
+
+// MakeDirectoryContext is MakeDirectory with the context of the call.
+func (r *Cloudinary) MakeDirectoryContext(ctx context.Context, directory string) error {
+	return r.withContext(ctx).MakeDirectory(directory)
+}
+
+// MimeTypeContext is MimeType with the context of the call.
+func (r *Cloudinary) MimeTypeContext(ctx context.Context, file string) (string, error) {
+	return r.withContext(ctx).MimeType(file)
+}
+
+// MissingContext is Missing with the context of the call.
+func (r *Cloudinary) MissingContext(ctx context.Context, file string) bool {
+	return r.withContext(ctx).Missing(file)
+}
+
+// MoveContext is Move with the context of the call.
+func (r *Cloudinary) MoveContext(ctx context.Context, source, destination string) error {
+	return r.withContext(ctx).Move(source, destination)
+}
+
+// PutContext is Put with the context of the call.
+func (r *Cloudinary) PutContext(ctx context.Context, file, content string) error {
+	return r.withContext(ctx).Put(file, content)
+}
+
+// PutFileContext is PutFile with the context of the call.
+func (r *Cloudinary) PutFileContext(ctx context.Context, path string, source filesystem.File) (string, error) {
+	return r.withContext(ctx).PutFile(path, source)
+}
+
+// PutFileAsContext is PutFileAs with the context of the call.
+func (r *Cloudinary) PutFileAsContext(ctx context.Context, path string, source filesystem.File, name string) (string, error) {
+	return r.withContext(ctx).PutFileAs(path, source, name)
+}
+
+// PingContext is Ping with the context of the call.
+func (r *Cloudinary) PingContext(ctx context.Context) error {
+	return r.withContext(ctx).Ping()
+}
+
+// SizeContext is Size with the context of the call.
+func (r *Cloudinary) SizeContext(ctx context.Context, file string) (int64, error) {
+	return r.withContext(ctx).Size(file)
+}
+
+// UrlContext is Url with the context of the call.
+func (r *Cloudinary) UrlContext(ctx context.Context, file string) string {
+	return r.withContext(ctx).Url(file)
+}
+
+// ArchiveContext is Archive with the context of the call.
+func (r *Cloudinary) ArchiveContext(ctx context.Context, options ArchiveOptions) (string, error) {
+	return r.withContext(ctx).Archive(options)
+}
+
+// ArchiveToContext is ArchiveTo with the context of the call.
+func (r *Cloudinary) ArchiveToContext(ctx context.Context, writer io.Writer, options ArchiveOptions) error {
+	return r.withContext(ctx).ArchiveTo(writer, options)
+}
+
+// PutFileCheckedContext is PutFileChecked with the context of the call.
+func (r *Cloudinary) PutFileCheckedContext(ctx context.Context, path string, source filesystem.File, name string, policy DuplicatePolicy) (string, []string, error) {
+	return r.withContext(ctx).PutFileChecked(path, source, name, policy)
+}
+
+// FindDuplicatesContext is FindDuplicates with the context of the call.
+func (r *Cloudinary) FindDuplicatesContext(ctx context.Context, prefix string, options DuplicateOptions) ([]DuplicateGroup, error) {
+	return r.withContext(ctx).FindDuplicates(prefix, options)
+}
+
+// EagerContext is Eager with the context of the call.
+func (r *Cloudinary) EagerContext(ctx context.Context, file string, options EagerOptions, transformations ...string) (*EagerJob, error) {
+	return r.withContext(ctx).Eager(file, options, transformations...)
+}
+
+// ImageInfoContext is ImageInfo with the context of the call.
+func (r *Cloudinary) ImageInfoContext(ctx context.Context, file string) (*ImageInfo, error) {
+	return r.withContext(ctx).ImageInfo(file)
+}
+
+// PutImageContext is PutImage with the context of the call.
+func (r *Cloudinary) PutImageContext(ctx context.Context, path string, source filesystem.File, name string) (*ImageInfo, error) {
+	return r.withContext(ctx).PutImage(path, source, name)
+}
+
+// SetContextMetadataContext is SetContextMetadata with the context of the call.
+func (r *Cloudinary) SetContextMetadataContext(ctx context.Context, file string, context map[string]string) error {
+	return r.withContext(ctx).SetContextMetadata(file, context)
+}
+
+// GetContextMetadataContext is GetContextMetadata with the context of the call.
+func (r *Cloudinary) GetContextMetadataContext(ctx context.Context, file string) (map[string]string, error) {
+	return r.withContext(ctx).GetContextMetadata(file)
+}
+
+// SetMetadataContext is SetMetadata with the context of the call.
+func (r *Cloudinary) SetMetadataContext(ctx context.Context, file string, values map[string]any) error {
+	return r.withContext(ctx).SetMetadata(file, values)
+}
+
+// GetMetadataContext is GetMetadata with the context of the call.
+func (r *Cloudinary) GetMetadataContext(ctx context.Context, file string) (map[string]any, error) {
+	return r.withContext(ctx).GetMetadata(file)
+}
+
+// MetadataFieldsContext is MetadataFields with the context of the call.
+func (r *Cloudinary) MetadataFieldsContext(ctx context.Context) ([]metadata.Field, error) {
+	return r.withContext(ctx).MetadataFields()
+}
+
+// AddMetadataFieldContext is AddMetadataField with the context of the call.
+func (r *Cloudinary) AddMetadataFieldContext(ctx context.Context, field metadata.Field) error {
+	return r.withContext(ctx).AddMetadataField(field)
+}
+
+// DeleteMetadataFieldContext is DeleteMetadataField with the context of the call.
+func (r *Cloudinary) DeleteMetadataFieldContext(ctx context.Context, externalID string) error {
+	return r.withContext(ctx).DeleteMetadataField(externalID)
+}
+
+// UpdateMetadataDataSourceContext is UpdateMetadataDataSource with the context of the call.
+func (r *Cloudinary) UpdateMetadataDataSourceContext(ctx context.Context, externalID string, values ...metadata.DataSourceValue) error {
+	return r.withContext(ctx).UpdateMetadataDataSource(externalID, values...)
+}
+
+// DeleteMetadataDataSourceContext is DeleteMetadataDataSource with the context of the call.
+func (r *Cloudinary) DeleteMetadataDataSourceContext(ctx context.Context, externalID string, entries ...string) error {
+	return r.withContext(ctx).DeleteMetadataDataSource(externalID, entries...)
+}
+
+// AddTagsContext is AddTags with the context of the call.
+func (r *Cloudinary) AddTagsContext(ctx context.Context, tag string, file ...string) error {
+	return r.withContext(ctx).AddTags(tag, file...)
+}
+
+// RemoveTagsContext is RemoveTags with the context of the call.
+func (r *Cloudinary) RemoveTagsContext(ctx context.Context, tag string, file ...string) error {
+	return r.withContext(ctx).RemoveTags(tag, file...)
+}
+
+// ReplaceTagsContext is ReplaceTags with the context of the call.
+func (r *Cloudinary) ReplaceTagsContext(ctx context.Context, tag string, file ...string) error {
+	return r.withContext(ctx).ReplaceTags(tag, file...)
+}
+
+// ClearTagsContext is ClearTags with the context of the call.
+func (r *Cloudinary) ClearTagsContext(ctx context.Context, file ...string) error {
+	return r.withContext(ctx).ClearTags(file...)
+}
+
+// FilesByTagContext is FilesByTag with the context of the call.
+func (r *Cloudinary) FilesByTagContext(ctx context.Context, tag string) ([]string, error) {
+	return r.withContext(ctx).FilesByTag(tag)
+}
+
+// VerifyUploadContext is VerifyUpload with the context of the call.
+func (r *Cloudinary) VerifyUploadContext(ctx context.Context, response UploadResponse, options SignedUploadOptions) error {
+	return r.withContext(ctx).VerifyUpload(response, options)
+}
+
+// UsageContext is Usage with the context of the call.
+func (r *Cloudinary) UsageContext(ctx context.Context) (*Usage, error) {
+	return r.withContext(ctx).Usage()
+}
+
+// VideoInfoContext is VideoInfo with the context of the call.
+func (r *Cloudinary) VideoInfoContext(ctx context.Context, file string) (*VideoInfo, error) {
+	return r.withContext(ctx).VideoInfo(file)
+}
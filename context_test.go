@@ -0,0 +1,55 @@
+package cloudinary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextVariants(t *testing.T) {
+	server := newExplicitServer()
+	defer server.Close()
+
+	// The context of the driver isn't used by the variants.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	driver := newServerDriver(t, server.URL, nil).WithContextDriver(canceled)
+
+	size, err := driver.SizeContext(context.Background(), "intro")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1024), size)
+	exists, err := driver.CheckContext(context.Background(), "outro")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	_, err = driver.Size("intro")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = driver.CheckContext(canceled, "intro")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = driver.GetContext(canceled, "intro")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestContextPagination(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The listing never ends, until the context is canceled.
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		_, _ = w.Write([]byte(`{"resources":[{"public_id":"intro"}],"next_cursor":"next"}`))
+	}))
+	defer server.Close()
+
+	files, err := newServerDriver(t, server.URL, nil).AllFilesContext(ctx, "")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, files)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,160 @@
+package cloudinary
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/goravel/framework/contracts/filesystem"
+	"github.com/goravel/framework/contracts/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Driver is the filesystem driver with the Cloudinary specific operations, it's what the
+// facades.Cloudinary facade returns.
+type Driver interface {
+	filesystem.Driver
+
+	// Check checks if a file, or a directory when the path ends with a slash, exists, and returns the
+	// errors that prevent knowing it.
+	Check(file string) (bool, error)
+	// WithContextDriver is WithContext returning the Driver, the Context variants of the operations can
+	// be used instead to pass the context of a single call.
+	WithContextDriver(ctx context.Context) Driver
+	// WithDeliveryType returns a copy of the driver that uses the delivery type instead of the one of the disk.
+	WithDeliveryType(deliveryType string) Driver
+	// WithLogger returns a copy of the driver that logs to the logger.
+	WithLogger(logger log.Writer) Driver
+	// WithMetrics returns a copy of the driver that reports its operations to the metrics.
+	WithMetrics(metrics Metrics) Driver
+	// WithTracerProvider returns a copy of the driver that traces its operations with the provider.
+	WithTracerProvider(provider trace.TracerProvider) Driver
+
+	// Archive returns a signed URL that generates and downloads the archive.
+	Archive(options ArchiveOptions) (string, error)
+	// ArchiveTo streams the archive to the writer.
+	ArchiveTo(writer io.Writer, options ArchiveOptions) error
+
+	// PutFileChecked stores a new file on the disk after looking for stored files with the same content.
+	PutFileChecked(path string, source filesystem.File, name string, policy DuplicatePolicy) (string, []string, error)
+	// FindDuplicates groups the files under the prefix that have the same content.
+	FindDuplicates(prefix string, options DuplicateOptions) ([]DuplicateGroup, error)
+
+	// Eager generates the transformations of a file ahead of time.
+	Eager(file string, options EagerOptions, transformations ...string) (*EagerJob, error)
+	// WithEager returns a copy of the driver that generates the transformations of the files it uploads.
+	WithEager(options EagerOptions, transformations ...string) Driver
+	// PendingEagerJobs returns the async eager jobs of the disk that haven't been completed yet.
+	PendingEagerJobs() []*EagerJob
+
+	// ImageInfo returns the analysis of an image.
+	ImageInfo(file string) (*ImageInfo, error)
+	// PutImage stores a new image on the disk and returns its analysis.
+	PutImage(path string, source filesystem.File, name string) (*ImageInfo, error)
+
+	// SetContextMetadata adds the contextual metadata (key=value) to a file.
+	SetContextMetadata(file string, context map[string]string) error
+	// GetContextMetadata returns the contextual metadata of a file.
+	GetContextMetadata(file string) (map[string]string, error)
+	// SetMetadata sets the structured metadata of a file.
+	SetMetadata(file string, values map[string]any) error
+	// GetMetadata returns the structured metadata of a file.
+	GetMetadata(file string) (map[string]any, error)
+	// MetadataFields returns all the structured metadata field definitions.
+	MetadataFields() ([]metadata.Field, error)
+	// AddMetadataField creates a structured metadata field definition.
+	AddMetadataField(field metadata.Field) error
+	// DeleteMetadataField deletes a structured metadata field definition.
+	DeleteMetadataField(externalID string) error
+	// UpdateMetadataDataSource updates the datasource of an enum or set metadata field.
+	UpdateMetadataDataSource(externalID string, values ...metadata.DataSourceValue) error
+	// DeleteMetadataDataSource deletes the given entries from the datasource of an enum or set metadata field.
+	DeleteMetadataDataSource(externalID string, entries ...string) error
+
+	// AddTags adds a tag to the given files.
+	AddTags(tag string, file ...string) error
+	// RemoveTags removes a tag from the given files.
+	RemoveTags(tag string, file ...string) error
+	// ReplaceTags replaces all the existing tags of the given files with a tag.
+	ReplaceTags(tag string, file ...string) error
+	// ClearTags removes all the tags from the given files.
+	ClearTags(file ...string) error
+	// FilesByTag returns all the files with the given tag.
+	FilesByTag(tag string) ([]string, error)
+
+	// SignUpload returns the signed parameters the client needs to upload a file straight to Cloudinary.
+	SignUpload(path string, options SignedUploadOptions) (*SignedUpload, error)
+	// VerifyUpload verifies the signature of a direct upload response.
+	VerifyUpload(response UploadResponse, options SignedUploadOptions) error
+
+	// Ping checks that the API is reachable with the credentials of the disk.
+	Ping() error
+	// Usage returns the usage of the account of the disk.
+	Usage() (*Usage, error)
+	// RateLimit returns the Admin API rate limit of the account of the disk.
+	RateLimit() RateLimit
+
+	// VideoPosterUrl returns the url of the video frame at the offset in seconds.
+	VideoPosterUrl(file string, offset float64, format string) (string, error)
+	// VideoStreamUrl returns the adaptive streaming manifest url of a video for a streaming profile.
+	VideoStreamUrl(file, profile, format string) (string, error)
+	// VideoClipUrl returns the url of a trimmed video.
+	VideoClipUrl(file string, clip VideoClip, format string) (string, error)
+	// VideoAudioUrl returns the url of the audio track of a video.
+	VideoAudioUrl(file, format string) (string, error)
+	// VideoTranscodeUrl returns the url of a video transcoded to the format.
+	VideoTranscodeUrl(file, format, codec string) (string, error)
+	// VideoInfo returns the metadata of a video.
+	VideoInfo(file string) (*VideoInfo, error)
+
+	// The Context variants use the context for their API calls instead of the one of the driver.
+	AllDirectoriesContext(ctx context.Context, path string) ([]string, error)
+	AllFilesContext(ctx context.Context, path string) ([]string, error)
+	CheckContext(ctx context.Context, file string) (bool, error)
+	CopyContext(ctx context.Context, source, destination string) error
+	DeleteContext(ctx context.Context, file ...string) error
+	DeleteDirectoryContext(ctx context.Context, directory string) error
+	DirectoriesContext(ctx context.Context, path string) ([]string, error)
+	ExistsContext(ctx context.Context, file string) bool
+	FilesContext(ctx context.Context, path string) ([]string, error)
+	GetContext(ctx context.Context, file string) (string, error)
+	GetBytesContext(ctx context.Context, file string) ([]byte, error)
+	LastModifiedContext(ctx context.Context, file string) (time.Time, error)
+	MakeDirectoryContext(ctx context.Context, directory string) error
+	MimeTypeContext(ctx context.Context, file string) (string, error)
+	MissingContext(ctx context.Context, file string) bool
+	MoveContext(ctx context.Context, source, destination string) error
+	PutContext(ctx context.Context, file, content string) error
+	PutFileContext(ctx context.Context, path string, source filesystem.File) (string, error)
+	PutFileAsContext(ctx context.Context, path string, source filesystem.File, name string) (string, error)
+	PingContext(ctx context.Context) error
+	SizeContext(ctx context.Context, file string) (int64, error)
+	UrlContext(ctx context.Context, file string) string
+	ArchiveContext(ctx context.Context, options ArchiveOptions) (string, error)
+	ArchiveToContext(ctx context.Context, writer io.Writer, options ArchiveOptions) error
+	PutFileCheckedContext(ctx context.Context, path string, source filesystem.File, name string, policy DuplicatePolicy) (string, []string, error)
+	FindDuplicatesContext(ctx context.Context, prefix string, options DuplicateOptions) ([]DuplicateGroup, error)
+	EagerContext(ctx context.Context, file string, options EagerOptions, transformations ...string) (*EagerJob, error)
+	ImageInfoContext(ctx context.Context, file string) (*ImageInfo, error)
+	PutImageContext(ctx context.Context, path string, source filesystem.File, name string) (*ImageInfo, error)
+	SetContextMetadataContext(ctx context.Context, file string, context map[string]string) error
+	GetContextMetadataContext(ctx context.Context, file string) (map[string]string, error)
+	SetMetadataContext(ctx context.Context, file string, values map[string]any) error
+	GetMetadataContext(ctx context.Context, file string) (map[string]any, error)
+	MetadataFieldsContext(ctx context.Context) ([]metadata.Field, error)
+	AddMetadataFieldContext(ctx context.Context, field metadata.Field) error
+	DeleteMetadataFieldContext(ctx context.Context, externalID string) error
+	UpdateMetadataDataSourceContext(ctx context.Context, externalID string, values ...metadata.DataSourceValue) error
+	DeleteMetadataDataSourceContext(ctx context.Context, externalID string, entries ...string) error
+	AddTagsContext(ctx context.Context, tag string, file ...string) error
+	RemoveTagsContext(ctx context.Context, tag string, file ...string) error
+	ReplaceTagsContext(ctx context.Context, tag string, file ...string) error
+	ClearTagsContext(ctx context.Context, file ...string) error
+	FilesByTagContext(ctx context.Context, tag string) ([]string, error)
+	VerifyUploadContext(ctx context.Context, response UploadResponse, options SignedUploadOptions) error
+	UsageContext(ctx context.Context) (*Usage, error)
+	VideoInfoContext(ctx context.Context, file string) (*VideoInfo, error)
+}
+
+var _ Driver = (*Cloudinary)(nil)
@@ -0,0 +1,333 @@
+package cloudinary
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/goravel/framework/contracts/filesystem"
+	"github.com/goravel/framework/support/str"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DuplicatePolicy is what happens when a stored file has the same content as the uploaded one.
+type DuplicatePolicy string
+
+const (
+	// DuplicatesUpload uploads the file anyway.
+	DuplicatesUpload DuplicatePolicy = "upload"
+	// DuplicatesReuse doesn't upload the file and returns the stored one instead.
+	DuplicatesReuse DuplicatePolicy = "reuse"
+	// DuplicatesSkip doesn't upload the file and returns a *DuplicateError.
+	DuplicatesSkip DuplicatePolicy = "skip"
+)
+
+// DuplicateError is returned when an upload is skipped because of its duplicates.
+type DuplicateError struct {
+	File       string
+	Duplicates []string
+}
+
+func (r *DuplicateError) Error() string {
+	return fmt.Sprintf("%s is a duplicate of %s", r.File, strings.Join(r.Duplicates, ", "))
+}
+
+// validDuplicatePolicy checks that the policy is known, an empty policy doesn't look for duplicates.
+func validDuplicatePolicy(policy DuplicatePolicy) error {
+	switch policy {
+	case "", DuplicatesUpload, DuplicatesReuse, DuplicatesSkip:
+		return nil
+	default:
+		return fmt.Errorf("unsupported duplicate policy: %s", policy)
+	}
+}
+
+// DuplicateOptions configures how FindDuplicates compares the files.
+type DuplicateOptions struct {
+	// Phash compares the images by perceptual hash instead of etag, which also finds resized
+	// or re-encoded copies. It fetches the analysis of every image, so it is slow for large folders.
+	Phash bool
+	// MaxDistance is the maximum number of different bits between two similar perceptual hashes.
+	MaxDistance int
+}
+
+// DuplicateGroup is a set of files with the same or similar content.
+type DuplicateGroup struct {
+	// Key is the shared etag, or the perceptual hash of the first file.
+	Key   string
+	Files []string
+}
+
+// PutFileChecked stores a new file on the disk after looking for stored files with the same content,
+// and returns them along with the path of the file. The file name is kept if name is empty.
+//
+// PutFile and PutFileAs use the policy of the "duplicates" disk option, an empty policy doesn't look
+// for duplicates.
+func (r *Cloudinary) PutFileChecked(path string, source filesystem.File, name string, policy DuplicatePolicy) (_ string, _ []string, err error) {
+	r, op := r.startOperation("PutFileChecked", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
+
+	return r.putFile(path, source, name, policy)
+}
+
+func (r *Cloudinary) putFile(path string, source filesystem.File, name string, policy DuplicatePolicy) (string, []string, error) {
+	if err := validDuplicatePolicy(policy); err != nil {
+		return "", nil, err
+	}
+	if err := checkPath(path); err != nil {
+		return "", nil, err
+	}
+	if name != "" {
+		if err := checkFile(name); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var duplicates []string
+	if policy != "" {
+		etag, err := fileEtag(source.File())
+		if err != nil {
+			return "", nil, err
+		}
+		if duplicates, err = r.filesByEtag(etag); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if len(duplicates) > 0 {
+		switch policy {
+		case DuplicatesReuse:
+			return duplicates[0], duplicates, nil
+		case DuplicatesSkip:
+			return "", duplicates, &DuplicateError{File: source.File(), Duplicates: duplicates}
+		}
+	}
+
+	// If the file is created in a folder directly, we can't check if the folder exists.
+	// So we need to create the top folder first.
+	if err := r.makeDirectories(str.Of(path).Finish("/").String()); err != nil {
+		return "", nil, err
+	}
+
+	uploadResult, err := r.upload(source.File(), uploader.UploadParams{
+		Folder:         rootPath(r.root, path),
+		PublicID:       name,
+		UseFilename:    api.Bool(true),
+		UniqueFilename: api.Bool(false),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	file, _ := relativePath(r.root, uploadResult.PublicID)
+	return file, duplicates, nil
+}
+
+// FindDuplicates groups the files under the prefix that have the same content.
+func (r *Cloudinary) FindDuplicates(prefix string, options DuplicateOptions) (_ []DuplicateGroup, err error) {
+	r, op := r.startOperation("FindDuplicates", attribute.String("cloudinary.path", prefix))
+	defer func() { op.end(err) }()
+
+	expression := fmt.Sprintf("public_id:%s* AND type:%s", rootPath(r.root, prefix), r.deliveryType())
+	if options.Phash {
+		expression += " AND resource_type:image"
+	}
+
+	hashes := make(map[string]string)
+	nextCursor := ""
+	for {
+		if err := r.ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, err := r.instance.Admin.Search(r.ctx, search.Query{
+			Expression: expression,
+			MaxResults: 500,
+			NextCursor: nextCursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := r.apiError("find duplicates", result.Error); err != nil {
+			return nil, err
+		}
+
+		for _, asset := range result.Assets {
+			file, _ := relativePath(r.root, asset.PublicID)
+			if !options.Phash {
+				hashes[file] = asset.Etag
+				continue
+			}
+			if err := r.ctx.Err(); err != nil {
+				return nil, err
+			}
+			// The search API doesn't return perceptual hashes.
+			info, err := r.instance.Admin.Asset(r.ctx, admin.AssetParams{
+				AssetType:    api.Image,
+				DeliveryType: api.DeliveryType(asset.Type),
+				PublicID:     asset.PublicID,
+				Phash:        api.Bool(true),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := r.apiError("find duplicates", info.Error); err != nil {
+				return nil, err
+			}
+			hashes[file] = info.Phash
+		}
+
+		nextCursor = result.NextCursor
+		if nextCursor == "" {
+			break
+		}
+	}
+
+	if options.Phash {
+		return groupByPhash(hashes, options.MaxDistance), nil
+	}
+	return groupByEtag(hashes), nil
+}
+
+func (r *Cloudinary) duplicatePolicy() DuplicatePolicy {
+	return DuplicatePolicy(r.config.GetString(fmt.Sprintf("filesystems.disks.%s.duplicates", r.disk)))
+}
+
+func (r *Cloudinary) filesByEtag(etag string) ([]string, error) {
+	// Files of other disks sharing the cloud aren't duplicates of this one.
+	expression := fmt.Sprintf("etag=%s AND type:%s", etag, r.deliveryType())
+	if root := validPath(r.root); root != "" {
+		expression += fmt.Sprintf(" AND public_id:%s/*", root)
+	}
+
+	var files []string
+	nextCursor := ""
+	for {
+		if err := r.ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, err := r.instance.Admin.Search(r.ctx, search.Query{
+			Expression: expression,
+			SortBy: []search.SortByField{
+				{"created_at": search.Ascending},
+			},
+			MaxResults: 500,
+			NextCursor: nextCursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := r.apiError("duplicates", result.Error); err != nil {
+			return nil, err
+		}
+
+		for _, asset := range result.Assets {
+			if file, ok := relativePath(r.root, asset.PublicID); ok {
+				files = append(files, file)
+			}
+		}
+
+		nextCursor = result.NextCursor
+		if nextCursor == "" {
+			break
+		}
+	}
+	return files, nil
+}
+
+// fileEtag returns the MD5 digest of a file, which Cloudinary uses as etag.
+func fileEtag(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hashFunc := md5.New()
+	if _, err := io.Copy(hashFunc, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hashFunc.Sum(nil)), nil
+}
+
+func groupByEtag(etags map[string]string) []DuplicateGroup {
+	files := make(map[string][]string)
+	for file, etag := range etags {
+		if etag != "" {
+			files[etag] = append(files[etag], file)
+		}
+	}
+
+	var groups []DuplicateGroup
+	for etag, group := range files {
+		if len(group) > 1 {
+			sort.Strings(group)
+			groups = append(groups, DuplicateGroup{Key: etag, Files: group})
+		}
+	}
+	sortDuplicateGroups(groups)
+	return groups
+}
+
+// groupByPhash groups the files whose perceptual hashes are at most maxDistance bits apart,
+// directly or through other files of the group.
+func groupByPhash(phashes map[string]string, maxDistance int) []DuplicateGroup {
+	var files []string
+	hashes := make(map[string]uint64)
+	for file, phash := range phashes {
+		hash, err := strconv.ParseUint(phash, 16, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, file)
+		hashes[file] = hash
+	}
+	sort.Strings(files)
+
+	parents := make([]int, len(files))
+	for i := range parents {
+		parents[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parents[i] != i {
+			parents[i] = root(parents[i])
+		}
+		return parents[i]
+	}
+	for i := range files {
+		for j := i + 1; j < len(files); j++ {
+			if bits.OnesCount64(hashes[files[i]]^hashes[files[j]]) <= maxDistance {
+				parents[root(j)] = root(i)
+			}
+		}
+	}
+
+	members := make(map[int][]string)
+	for i, file := range files {
+		members[root(i)] = append(members[root(i)], file)
+	}
+
+	var groups []DuplicateGroup
+	for _, group := range members {
+		if len(group) > 1 {
+			groups = append(groups, DuplicateGroup{Key: phashes[group[0]], Files: group})
+		}
+	}
+	sortDuplicateGroups(groups)
+	return groups
+}
+
+func sortDuplicateGroups(groups []DuplicateGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Files[0] < groups[j].Files[0]
+	})
+}
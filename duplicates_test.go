@@ -0,0 +1,98 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileEtag(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "goravel.txt")
+	assert.Nil(t, os.WriteFile(file, []byte("Goravel"), 0644))
+
+	etag, err := fileEtag(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "c3df4521494ea91230efeb22a054eed1", etag)
+
+	_, err = fileEtag(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.NotNil(t, err)
+}
+
+func TestGroupByEtag(t *testing.T) {
+	groups := groupByEtag(map[string]string{
+		"avatars/b":   "etag1",
+		"avatars/a":   "etag1",
+		"avatars/c":   "etag2",
+		"avatars/d":   "etag3",
+		"avatars/e":   "etag3",
+		"avatars/raw": "",
+	})
+	assert.Equal(t, []DuplicateGroup{
+		{Key: "etag1", Files: []string{"avatars/a", "avatars/b"}},
+		{Key: "etag3", Files: []string{"avatars/d", "avatars/e"}},
+	}, groups)
+}
+
+func TestGroupByPhash(t *testing.T) {
+	phashes := map[string]string{
+		"avatars/a":       "ba19c8ab5fa05a59",
+		"avatars/a_small": "ba19c8ab5fa05a58",
+		"avatars/a_tiny":  "ba19c8ab5fa05a5a",
+		"avatars/b":       "0000000000000000",
+		"avatars/broken":  "not a hash",
+	}
+
+	assert.Equal(t, []DuplicateGroup{
+		{Key: "ba19c8ab5fa05a59", Files: []string{"avatars/a", "avatars/a_small", "avatars/a_tiny"}},
+	}, groupByPhash(phashes, 1))
+	assert.Empty(t, groupByPhash(phashes, 0))
+}
+
+func TestPutFileCheckedPolicy(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"resources":[]}`))
+	}))
+	defer server.Close()
+
+	// The policy is checked before the file is hashed and searched for.
+	driver := newServerDriver(t, server.URL, nil)
+	_, _, err := driver.PutFileChecked("avatars", &File{path: "missing.png"}, "", "ignore")
+	assert.EqualError(t, err, "unsupported duplicate policy: ignore")
+	assert.Zero(t, calls)
+}
+
+func TestFilesByEtag(t *testing.T) {
+	var expressions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query struct {
+			Expression string `json:"expression"`
+			MaxResults int    `json:"max_results"`
+			NextCursor string `json:"next_cursor"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&query))
+		assert.Equal(t, 500, query.MaxResults)
+		expressions = append(expressions, query.Expression)
+		if query.NextCursor == "" {
+			_, _ = w.Write([]byte(`{"resources":[{"public_id":"goravel/1"}],"next_cursor":"next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"resources":[{"public_id":"goravel/a/2"},{"public_id":"goravelfake/3"}]}`))
+	}))
+	defer server.Close()
+
+	// The search is scoped to the root and reads all the pages.
+	files, err := newServerDriver(t, server.URL, map[string]any{"root": "goravel"}).filesByEtag("etag")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "a/2"}, files)
+	assert.Equal(t, []string{
+		"etag=etag AND type:upload AND public_id:goravel/*",
+		"etag=etag AND type:upload AND public_id:goravel/*",
+	}, expressions)
+}
@@ -0,0 +1,298 @@
+package cloudinary
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EagerOptions configures how the eager transformations of a file are generated.
+type EagerOptions struct {
+	// Async generates the transformations in the background, the returned job is completed
+	// when Cloudinary sends the eager notification.
+	Async bool
+	// NotificationURL receives the eager notification, defaults to the eager_notification_url disk option.
+	NotificationURL string
+	// OnComplete is called when the WebhookController receives the notification of an async job.
+	OnComplete func(notification *EagerNotification)
+}
+
+// EagerJob is a batch of eager transformations generated for a file.
+type EagerJob struct {
+	Disk            string
+	BatchID         string
+	PublicID        string
+	Transformations []string
+
+	// results and completed are set once the notification of the job is received, they're guarded by
+	// the lock of eagerJobs.
+	results    []EagerResult
+	completed  bool
+	onComplete func(notification *EagerNotification)
+	added      time.Time
+}
+
+// Pending reports whether the transformations are still being generated.
+func (r *EagerJob) Pending() bool {
+	eagerJobs.mu.Lock()
+	defer eagerJobs.mu.Unlock()
+	return r.BatchID != "" && !r.completed
+}
+
+// Results returns the generated transformations, none while the job is pending.
+func (r *EagerJob) Results() []EagerResult {
+	eagerJobs.mu.Lock()
+	defer eagerJobs.mu.Unlock()
+	return append([]EagerResult(nil), r.results...)
+}
+
+// Eager generates the transformations of a file ahead of time.
+func (r *Cloudinary) Eager(file string, options EagerOptions, transformations ...string) (_ *EagerJob, err error) {
+	r, op := r.startOperation("Eager", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	if len(transformations) == 0 {
+		return nil, fmt.Errorf("no eager transformations given for %s", file)
+	}
+
+	asset, err := r.getAsset(validPath(file))
+	if err != nil {
+		return nil, err
+	}
+
+	notificationURL := options.NotificationURL
+	if notificationURL == "" {
+		notificationURL = r.config.GetString(fmt.Sprintf("filesystems.disks.%s.eager_notification_url", r.disk))
+	}
+	result, err := r.instance.Upload.Explicit(r.ctx, uploader.ExplicitParams{
+		PublicID:             asset.PublicID,
+		Type:                 api.DeliveryType(r.deliveryType()),
+		ResourceType:         asset.ResourceType,
+		Eager:                strings.Join(transformations, "|"),
+		EagerAsync:           api.Bool(options.Async),
+		EagerNotificationURL: notificationURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("eager", result.Error); err != nil {
+		return nil, err
+	}
+
+	publicID, _ := relativePath(r.root, result.PublicID)
+	job := &EagerJob{
+		Disk:            r.disk,
+		BatchID:         batchID(result.Response),
+		PublicID:        publicID,
+		Transformations: transformations,
+		onComplete:      options.OnComplete,
+	}
+	if !options.Async {
+		job.BatchID = ""
+		for _, eager := range result.Eager {
+			job.results = append(job.results, EagerResult{
+				Transformation: eager.Transformation,
+				Width:          eager.Width,
+				Height:         eager.Height,
+				Bytes:          int64(eager.Bytes),
+				Format:         eager.Format,
+				URL:            eager.URL,
+				SecureURL:      eager.SecureURL,
+			})
+		}
+		return job, nil
+	}
+
+	eagerJobs.add(job)
+
+	return job, nil
+}
+
+// WithEager returns a copy of the driver that generates the transformations of the files it uploads,
+// instead of the ones of the eager disk option. The async jobs are tracked until their notification is
+// received, see PendingEagerJobs.
+func (r *Cloudinary) WithEager(options EagerOptions, transformations ...string) Driver {
+	driver := *r
+	driver.eager = &eagerUpload{options: options, transformations: transformations}
+	return &driver
+}
+
+// eagerUpload is the eager transformations of the uploads of a driver made by WithEager.
+type eagerUpload struct {
+	options         EagerOptions
+	transformations []string
+}
+
+// upload uploads a file with the eager transformations given by WithEager, or else the ones configured
+// for the disk:
+//
+//	"eager":                  []string{"c_fill,w_300,h_300"},
+//	"eager_async":            true,
+//	"eager_notification_url": "https://example.com/cloudinary/webhook",
+//
+// The async jobs of the disk option aren't tracked, their notification can only be handled by the
+// webhook events.
+func (r *Cloudinary) upload(file any, params uploader.UploadParams) (*uploader.UploadResult, error) {
+	eager := r.eager
+	if eager == nil {
+		transformations, _ := r.config.Get(fmt.Sprintf("filesystems.disks.%s.eager", r.disk)).([]string)
+		eager = &eagerUpload{transformations: transformations}
+		if len(transformations) > 0 {
+			eager.options.Async = r.config.GetBool(fmt.Sprintf("filesystems.disks.%s.eager_async", r.disk))
+		}
+	}
+	if len(eager.transformations) > 0 {
+		params.Eager = strings.Join(eager.transformations, "|")
+		params.EagerAsync = api.Bool(eager.options.Async)
+		params.EagerNotificationURL = eager.options.NotificationURL
+		if params.EagerNotificationURL == "" {
+			params.EagerNotificationURL = r.config.GetString(fmt.Sprintf("filesystems.disks.%s.eager_notification_url", r.disk))
+		}
+	}
+
+	if params.Type == "" {
+		params.Type = api.DeliveryType(r.deliveryType())
+	}
+
+	result, err := r.instance.Upload.Upload(withUploadRetry(r.ctx, params), file, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("upload file", result.Error); err != nil {
+		return nil, err
+	}
+	r.setResourceType(result.ResourceType)
+	r.setBytes(DirectionUpload, result.Bytes)
+	if r.eager != nil && eager.options.Async && len(eager.transformations) > 0 {
+		publicID, _ := relativePath(r.root, result.PublicID)
+		eagerJobs.add(&EagerJob{
+			Disk:            r.disk,
+			BatchID:         batchID(result.Response),
+			PublicID:        publicID,
+			Transformations: eager.transformations,
+			onComplete:      eager.options.OnComplete,
+		})
+	}
+	return result, nil
+}
+
+// PendingEagerJobs returns the async eager jobs of the disk that haven't been completed yet, the jobs
+// whose notification isn't received within a day are forgotten.
+func (r *Cloudinary) PendingEagerJobs() []*EagerJob {
+	return eagerJobs.pending(r.disk)
+}
+
+const (
+	// eagerJobTTL is how long an async job is tracked when its notification isn't received.
+	eagerJobTTL = 24 * time.Hour
+	// eagerJobLimit is the number of async jobs tracked, the oldest ones are forgotten first.
+	eagerJobLimit = 1000
+)
+
+// eagerJobs tracks the async eager jobs until their notification is received.
+var eagerJobs = &eagerJobRegistry{jobs: make(map[string]*EagerJob)}
+
+type eagerJobRegistry struct {
+	mu   sync.Mutex
+	jobs map[string]*EagerJob
+}
+
+func (r *eagerJobRegistry) add(job *EagerJob) {
+	if job.BatchID == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.prune(now)
+	if len(r.jobs) >= eagerJobLimit {
+		var oldest *EagerJob
+		for _, tracked := range r.jobs {
+			if oldest == nil || tracked.added.Before(oldest.added) {
+				oldest = tracked
+			}
+		}
+		delete(r.jobs, oldest.BatchID)
+	}
+	job.added = now
+	r.jobs[job.BatchID] = job
+}
+
+// complete marks the job of the notification as completed and calls its callback.
+func (r *eagerJobRegistry) complete(notification *EagerNotification) {
+	r.mu.Lock()
+	job, ok := r.jobs[notification.BatchID]
+	if ok {
+		delete(r.jobs, notification.BatchID)
+		job.results = notification.Eager
+		job.completed = true
+	}
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if job.onComplete != nil {
+		job.onComplete(notification)
+	}
+}
+
+func (r *eagerJobRegistry) pending(disk string) []*EagerJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prune(time.Now())
+
+	var jobs []*EagerJob
+	for _, job := range r.jobs {
+		if job.Disk == disk {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// prune forgets the jobs whose notification didn't come in time, the lock must be held.
+func (r *eagerJobRegistry) prune(now time.Time) {
+	for batchID, job := range r.jobs {
+		if now.Sub(job.added) > eagerJobTTL {
+			delete(r.jobs, batchID)
+		}
+	}
+}
+
+// batchID returns the batch ID of an async eager response, which the SDK doesn't expose.
+func batchID(response any) string {
+	// The SDK sets the response to a pointer to the decoded JSON.
+	switch raw := response.(type) {
+	case *any:
+		if raw != nil {
+			response = *raw
+		}
+	case *map[string]any:
+		if raw != nil {
+			response = *raw
+		}
+	}
+	fields, ok := response.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if id, ok := fields["batch_id"].(string); ok {
+		return id
+	}
+	eager, _ := fields["eager"].([]any)
+	for _, item := range eager {
+		if transformation, ok := item.(map[string]any); ok {
+			if id, ok := transformation["batch_id"].(string); ok {
+				return id
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchID(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expect   string
+	}{
+		{
+			name:     "top level",
+			response: `{"public_id":"avatars/goravel","batch_id":"top"}`,
+			expect:   "top",
+		},
+		{
+			name:     "eager item",
+			response: `{"public_id":"avatars/goravel","eager":[{"status":"processing","batch_id":"item","transformation":"w_100"}]}`,
+			expect:   "item",
+		},
+		{
+			name:     "sync",
+			response: `{"public_id":"avatars/goravel","eager":[{"transformation":"w_100","width":100}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var response any
+			assert.Nil(t, json.Unmarshal([]byte(test.response), &response))
+			assert.Equal(t, test.expect, batchID(&response))
+			fields := response.(map[string]any)
+			assert.Equal(t, test.expect, batchID(&fields))
+		})
+	}
+}
+
+func TestEagerJobRegistry(t *testing.T) {
+	registry := eagerJobs
+	defer func(jobs map[string]*EagerJob) { eagerJobs.jobs = jobs }(eagerJobs.jobs)
+	eagerJobs.jobs = make(map[string]*EagerJob)
+
+	var completed *EagerNotification
+	job := &EagerJob{
+		Disk:            "cloudinary",
+		BatchID:         "batch",
+		PublicID:        "avatars/goravel",
+		Transformations: []string{"w_100"},
+		onComplete: func(notification *EagerNotification) {
+			completed = notification
+		},
+	}
+	registry.add(job)
+	registry.add(&EagerJob{Disk: "cloudinary", PublicID: "avatars/sync"})
+	assert.True(t, job.Pending())
+	assert.Equal(t, []*EagerJob{job}, registry.pending("cloudinary"))
+	assert.Empty(t, registry.pending("s3"))
+
+	notification := &EagerNotification{
+		BatchID:  "batch",
+		PublicID: "avatars/goravel",
+		Eager:    []EagerResult{{Transformation: "w_100", Width: 100}},
+	}
+	registry.complete(notification)
+	assert.Equal(t, notification, completed)
+	assert.False(t, job.Pending())
+	assert.Equal(t, notification.Eager, job.Results())
+	assert.Empty(t, registry.pending("cloudinary"))
+
+	// A failed job has no results, but isn't pending anymore.
+	failed := &EagerJob{Disk: "cloudinary", BatchID: "failed"}
+	registry.add(failed)
+	registry.complete(&EagerNotification{BatchID: "failed"})
+	assert.False(t, failed.Pending())
+	assert.Empty(t, failed.Results())
+}
+
+func TestEagerJobRegistryBounds(t *testing.T) {
+	registry := &eagerJobRegistry{jobs: make(map[string]*EagerJob)}
+
+	registry.add(&EagerJob{Disk: "cloudinary", BatchID: "expired"})
+	registry.jobs["expired"].added = time.Now().Add(-eagerJobTTL - time.Minute)
+	registry.add(&EagerJob{Disk: "cloudinary", BatchID: "recent"})
+	assert.Len(t, registry.pending("cloudinary"), 1)
+
+	for i := range eagerJobLimit {
+		registry.add(&EagerJob{Disk: "cloudinary", BatchID: fmt.Sprint(i)})
+	}
+	assert.Len(t, registry.jobs, eagerJobLimit)
+	assert.NotContains(t, registry.jobs, "recent")
+	assert.Contains(t, registry.jobs, fmt.Sprint(eagerJobLimit-1))
+}
+
+func TestUploadEager(t *testing.T) {
+	defer func(jobs map[string]*EagerJob) { eagerJobs.jobs = jobs }(eagerJobs.jobs)
+	eagerJobs.jobs = make(map[string]*EagerJob)
+
+	var forms []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/folders/") {
+			_, _ = w.Write([]byte(`{"success":true}`))
+			return
+		}
+		_ = r.ParseMultipartForm(1 << 20)
+		forms = append(forms, fmt.Sprintf("%s %s %s", r.FormValue("eager"), r.FormValue("eager_async"), r.FormValue("eager_notification_url")))
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"public_id":"goravel/%d","resource_type":"raw","type":"upload","batch_id":"batch-%d"}`, len(forms), len(forms))))
+	}))
+	defer server.Close()
+
+	driver := newServerDriver(t, server.URL, map[string]any{"root": "goravel", "eager": []string{"w_100"}})
+	driver.config.(*mocksconfig.Config).On("GetBool", "filesystems.disks.cloudinary.eager_async").Return(true)
+	driver.config.(*mocksconfig.Config).On("GetString", "filesystems.disks.cloudinary.eager_notification_url").Return("https://example.com/webhook")
+
+	// The async jobs of the disk option aren't tracked.
+	assert.Nil(t, driver.Put("1.txt", "goravel"))
+	assert.Empty(t, driver.PendingEagerJobs())
+
+	var completed []EagerResult
+	eager := driver.WithEager(EagerOptions{Async: true, NotificationURL: "https://example.com/eager", OnComplete: func(notification *EagerNotification) {
+		completed = notification.Eager
+	}}, "c_fill,w_300", "e_grayscale")
+	assert.Nil(t, eager.Put("2.txt", "goravel"))
+	jobs := driver.PendingEagerJobs()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "batch-2", jobs[0].BatchID)
+	assert.Equal(t, "2", jobs[0].PublicID)
+	assert.True(t, jobs[0].Pending())
+
+	eagerJobs.complete(&EagerNotification{BatchID: "batch-2", Eager: []EagerResult{{Transformation: "c_fill,w_300"}}})
+	assert.Equal(t, []EagerResult{{Transformation: "c_fill,w_300"}}, completed)
+	assert.Equal(t, completed, jobs[0].Results())
+	assert.False(t, jobs[0].Pending())
+
+	assert.Equal(t, []string{
+		"w_100 true https://example.com/webhook",
+		"c_fill,w_300|e_grayscale true https://example.com/eager",
+	}, forms)
+}
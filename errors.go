@@ -0,0 +1,108 @@
+package cloudinary
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+var (
+	// ErrNotFound is returned when a file or a directory doesn't exist.
+	ErrNotFound = errors.New("cloudinary: not found")
+	// ErrRateLimited is returned when the rate limit of the account is exceeded, after the retries.
+	ErrRateLimited = errors.New("cloudinary: rate limited")
+	// ErrUnauthorized is returned when the credentials of the disk are invalid or not allowed to do the call.
+	ErrUnauthorized = errors.New("cloudinary: unauthorized")
+	// ErrInvalidPath is returned when a path can't be a public ID.
+	ErrInvalidPath = errors.New("cloudinary: invalid path")
+	// ErrNotRegistered is returned by the facade when &cloudinary.ServiceProvider{} isn't in the providers
+	// of the application.
+	ErrNotRegistered = errors.New("cloudinary: the service provider isn't registered")
+)
+
+// APIError is an error returned by the Cloudinary API. It matches ErrNotFound, ErrRateLimited and
+// ErrUnauthorized with errors.Is depending on its status.
+type APIError struct {
+	// Action is what the driver was doing, e.g. "delete file".
+	Action string
+	// Status is the HTTP status of the response, 0 when it's unknown.
+	Status int
+	// Message is the error message of Cloudinary.
+	Message string
+}
+
+func (r *APIError) Error() string {
+	if r.Status == 0 {
+		return fmt.Sprintf("%s error: %s", r.Action, r.Message)
+	}
+	return fmt.Sprintf("%s error: %s (status %d)", r.Action, r.Message, r.Status)
+}
+
+func (r *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return r.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return r.Status == 420 || r.Status == http.StatusTooManyRequests
+	case ErrUnauthorized:
+		return r.Status == http.StatusUnauthorized || r.Status == http.StatusForbidden
+	}
+	return false
+}
+
+// apiError returns the error of an API call when its response has one, nil otherwise. The status is the
+// one of the last API call of the operation, as the SDK doesn't return it.
+func (r *Cloudinary) apiError(action string, response api.ErrorResp) error {
+	if response.Message == "" {
+		return nil
+	}
+	status := 0
+	if op := operationFromContext(r.ctx); op != nil {
+		status = op.lastStatus()
+	}
+	return &APIError{Action: action, Status: status, Message: response.Message}
+}
+
+// errorResp converts the untyped error of some SDK results, which is the decoded JSON error.
+func errorResp(value any) api.ErrorResp {
+	switch value := value.(type) {
+	case nil:
+		return api.ErrorResp{}
+	case map[string]any:
+		if message, ok := value["message"].(string); ok && message != "" {
+			return api.ErrorResp{Message: message}
+		}
+	}
+	return api.ErrorResp{Message: fmt.Sprint(value)}
+}
+
+// responseError returns the error of a download response, nil when it succeeded.
+func responseError(action string, resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	message := resp.Header.Get("X-Cld-Error")
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{Action: action, Status: resp.StatusCode, Message: message}
+}
+
+// checkPath returns ErrInvalidPath when the path contains characters that public IDs can't contain.
+func checkPath(path string) error {
+	if strings.ContainsAny(path, `?&#\%<>`) {
+		return fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+	return nil
+}
+
+// checkFile returns ErrInvalidPath when the path can't be the public ID of a file.
+func checkFile(path string) error {
+	if strings.Trim(path, "/ ") == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+	return checkPath(path)
+}
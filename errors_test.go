@@ -0,0 +1,106 @@
+package cloudinary
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		status int
+		expect error
+	}{
+		{status: http.StatusNotFound, expect: ErrNotFound},
+		{status: 420, expect: ErrRateLimited},
+		{status: http.StatusTooManyRequests, expect: ErrRateLimited},
+		{status: http.StatusUnauthorized, expect: ErrUnauthorized},
+		{status: http.StatusForbidden, expect: ErrUnauthorized},
+		{status: http.StatusBadRequest},
+		{status: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.status), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{Action: "get file", Status: test.status, Message: "error"})
+			for _, sentinel := range []error{ErrNotFound, ErrRateLimited, ErrUnauthorized} {
+				assert.Equal(t, sentinel == test.expect, errors.Is(err, sentinel), sentinel.Error())
+			}
+
+			var apiErr *APIError
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, test.status, apiErr.Status)
+		})
+	}
+
+	assert.EqualError(t, &APIError{Action: "get file", Status: 404, Message: "Resource not found"}, "get file error: Resource not found (status 404)")
+	assert.EqualError(t, &APIError{Action: "get file", Message: "Resource not found"}, "get file error: Resource not found")
+}
+
+func TestCheckFile(t *testing.T) {
+	for _, path := range []string{"avatar.png", "avatars/goravel.png", "avatars/goravel 2.png"} {
+		assert.Nil(t, checkFile(path), path)
+	}
+	for _, path := range []string{"", "/", " ", "avatar?.png", "avatars/50%.png", "avatars/<goravel>.png", `avatars\goravel.png`} {
+		assert.ErrorIs(t, checkFile(path), ErrInvalidPath, path)
+	}
+	assert.Nil(t, checkPath(""))
+}
+
+func TestErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The SDK doesn't set the content type of the form.
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.Contains(string(body), "public_id=unauthorized&"):
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid Signature"}}`))
+		case strings.Contains(string(body), "public_id=limited&"):
+			w.WriteHeader(420)
+			_, _ = w.Write([]byte(`{"error":{"message":"Rate Limit Exceeded"}}`))
+		case strings.HasSuffix(r.URL.Path, "/upload"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid image file"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"Resource not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	driver := newServerDriver(t, server.URL, nil)
+
+	_, err := driver.Size("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "cloudinary: not found: missing")
+
+	// The other errors stop the lookup of the file.
+	_, err = driver.Size("unauthorized")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.EqualError(t, err, "get file error: Invalid Signature (status 401)")
+	_, err = driver.Size("limited")
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	// The error of an upload isn't ignored anymore.
+	err = driver.Put("avatar.png", "avatar")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, &APIError{Action: "upload file", Status: http.StatusBadRequest, Message: "Invalid image file"}, apiErr)
+
+	assert.ErrorIs(t, driver.Put("avatar?.png", "avatar"), ErrInvalidPath)
+	assert.ErrorIs(t, driver.Move("avatar.png", ""), ErrInvalidPath)
+	_, err = driver.PutFile("avatars?", &File{path: "logo.png"})
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, err = driver.PutFileAs("avatars", &File{path: "logo.png"}, "logo#1")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, _, err = driver.PutFileChecked("avatars", &File{path: "logo.png"}, " / ", DuplicatesUpload)
+	assert.ErrorIs(t, err, ErrInvalidPath)
+	_, err = driver.PutImage("avatars%", &File{path: "logo.png"}, "")
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
@@ -1,16 +1,16 @@
 package facades
 
 import (
-	"github.com/goravel/framework/contracts/filesystem"
-
 	"github.com/goravel/cloudinary"
 )
 
-func Cloudinary(disk string) (filesystem.Driver, error) {
-	instance, err := cloudinary.App.MakeWith(cloudinary.Binding, map[string]any{"disk": disk})
+// Cloudinary returns the driver of a disk, with the Cloudinary specific operations. The driver is made
+// once per disk and shared by the next calls.
+func Cloudinary(disk string) (cloudinary.Driver, error) {
+	driver, err := cloudinary.Disk(disk)
 	if err != nil {
 		return nil, err
 	}
 
-	return instance.(*cloudinary.Cloudinary), nil
+	return driver, nil
 }
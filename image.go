@@ -0,0 +1,173 @@
+package cloudinary
+
+import (
+	"encoding/json"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/goravel/framework/contracts/filesystem"
+	"github.com/goravel/framework/support/str"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ImageInfo is the analysis of an image.
+type ImageInfo struct {
+	PublicID string
+	Format   string
+	Width    int
+	Height   int
+	Bytes    int64
+	// Colors are the colors of the image, from the most to the least used.
+	Colors []ImageColor
+	// Predominant are the predominant colors of the image, named from the Google palette.
+	Predominant []ImageColor
+	Faces       []ImageRegion
+	// Metadata contains the EXIF, IPTC and XMP metadata of the image.
+	Metadata map[string]string
+	// Phash is the perceptual hash of the image.
+	Phash string
+}
+
+// ImageColor is a color and the percentage of the image it covers.
+type ImageColor struct {
+	Color      string
+	Percentage float64
+}
+
+// ImageRegion is a rectangle of an image, in pixels.
+type ImageRegion struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// ImageInfo returns the analysis of an image.
+func (r *Cloudinary) ImageInfo(file string) (_ *ImageInfo, err error) {
+	r, op := r.startOperation("ImageInfo", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.Asset(r.ctx, admin.AssetParams{
+		AssetType:     api.Image,
+		DeliveryType:  api.DeliveryType(r.deliveryType()),
+		PublicID:      rootPath(r.root, file),
+		Colors:        api.Bool(true),
+		Faces:         api.Bool(true),
+		ImageMetadata: api.Bool(true),
+		Phash:         api.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("image info", result.Error); err != nil {
+		return nil, err
+	}
+
+	info, err := imageInfo(result.Response)
+	if err != nil {
+		return nil, err
+	}
+	info.PublicID, _ = relativePath(r.root, info.PublicID)
+	return info, nil
+}
+
+// PutImage stores a new image on the disk and returns its analysis, the file name is kept if name is empty.
+func (r *Cloudinary) PutImage(path string, source filesystem.File, name string) (_ *ImageInfo, err error) {
+	r, op := r.startOperation("PutImage", attribute.String("cloudinary.path", path))
+	defer func() { op.end(err) }()
+
+	if err := checkPath(path); err != nil {
+		return nil, err
+	}
+	if name != "" {
+		if err := checkFile(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// If the file is created in a folder directly, we can't check if the folder exists.
+	// So we need to create the top folder first.
+	if err := r.makeDirectories(str.Of(path).Finish("/").String()); err != nil {
+		return nil, err
+	}
+
+	uploadResult, err := r.upload(source.File(), uploader.UploadParams{
+		Folder:         rootPath(r.root, path),
+		PublicID:       name,
+		UseFilename:    api.Bool(true),
+		UniqueFilename: api.Bool(false),
+		ResourceType:   string(api.Image),
+		Colors:         api.Bool(true),
+		Faces:          api.Bool(true),
+		ImageMetadata:  api.Bool(true),
+		Phash:          api.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := imageInfo(uploadResult.Response)
+	if err != nil {
+		return nil, err
+	}
+	info.PublicID, _ = relativePath(r.root, info.PublicID)
+	return info, nil
+}
+
+// imageInfo decodes the analysis from a raw asset or upload response, their analysis fields are the same
+// but the SDK only decodes them for the former.
+func imageInfo(response any) (*ImageInfo, error) {
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		PublicID    string             `json:"public_id"`
+		Format      string             `json:"format"`
+		Width       int                `json:"width"`
+		Height      int                `json:"height"`
+		Bytes       int64              `json:"bytes"`
+		Colors      [][]any            `json:"colors"`
+		Predominant map[string][][]any `json:"predominant"`
+		Faces       [][]int            `json:"faces"`
+		Metadata    map[string]string  `json:"image_metadata"`
+		Phash       string             `json:"phash"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	info := &ImageInfo{
+		PublicID:    result.PublicID,
+		Format:      result.Format,
+		Width:       result.Width,
+		Height:      result.Height,
+		Bytes:       result.Bytes,
+		Colors:      imageColors(result.Colors),
+		Predominant: imageColors(result.Predominant["google"]),
+		Metadata:    result.Metadata,
+		Phash:       result.Phash,
+	}
+	for _, face := range result.Faces {
+		if len(face) == 4 {
+			info.Faces = append(info.Faces, ImageRegion{X: face[0], Y: face[1], Width: face[2], Height: face[3]})
+		}
+	}
+	return info, nil
+}
+
+// imageColors converts the [color, percentage] pairs of a response.
+func imageColors(pairs [][]any) []ImageColor {
+	var colors []ImageColor
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			continue
+		}
+		color, _ := pair[0].(string)
+		percentage, _ := pair[1].(float64)
+		colors = append(colors, ImageColor{Color: color, Percentage: percentage})
+	}
+	return colors
+}
@@ -0,0 +1,64 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageInfo(t *testing.T) {
+	body, err := os.ReadFile("testdata/images/asset.json")
+	assert.Nil(t, err)
+	var response any
+	assert.Nil(t, json.Unmarshal(body, &response))
+
+	info, err := imageInfo(&response)
+	assert.Nil(t, err)
+	assert.Equal(t, &ImageInfo{
+		PublicID: "avatars/goravel",
+		Format:   "jpg",
+		Width:    864,
+		Height:   576,
+		Bytes:    120253,
+		Colors: []ImageColor{
+			{Color: "#162E02", Percentage: 6.7},
+			{Color: "#385B0C", Percentage: 6.3},
+			{Color: "#F3285C", Percentage: 5.0},
+		},
+		Predominant: []ImageColor{
+			{Color: "yellow", Percentage: 52.1},
+			{Color: "green", Percentage: 8.5},
+		},
+		Faces: []ImageRegion{
+			{X: 98, Y: 74, Width: 61, Height: 83},
+			{X: 140, Y: 130, Width: 52, Height: 71},
+		},
+		Metadata: map[string]string{
+			"Make":             "Canon",
+			"Model":            "Canon EOS 5D Mark III",
+			"DateTimeOriginal": "2024:06:25 10:21:31",
+		},
+		Phash: "ba19c8ab5fa05a59",
+	}, info)
+
+	info, err = imageInfo(&map[string]any{"public_id": "avatars/plain", "format": "png"})
+	assert.Nil(t, err)
+	assert.Equal(t, &ImageInfo{PublicID: "avatars/plain", Format: "png"}, info)
+}
+
+func TestImageInfoRoot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1_1/cloud/resources/image/upload/goravel/avatars/logo", r.URL.Path)
+		_, _ = w.Write([]byte(`{"public_id":"goravel/avatars/logo","resource_type":"image","type":"upload","format":"png"}`))
+	}))
+	defer server.Close()
+
+	driver := newServerDriver(t, server.URL, map[string]any{"root": "goravel"})
+	info, err := driver.ImageInfo("avatars/logo")
+	assert.Nil(t, err)
+	assert.Equal(t, &ImageInfo{PublicID: "avatars/logo", Format: "png"}, info)
+}
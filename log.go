@@ -0,0 +1,65 @@
+package cloudinary
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/goravel/framework/contracts/log"
+)
+
+// WithLogger returns a copy of the driver that logs to the logger instead of the log facade of the application.
+func (r *Cloudinary) WithLogger(logger log.Writer) Driver {
+	driver := *r
+	driver.log = logger
+	return &driver
+}
+
+// logger returns the logger of the driver, nil when there is neither one given nor an application.
+func (r *Cloudinary) logger() log.Writer {
+	if r.log != nil {
+		return r.log
+	}
+	return applicationLogger()
+}
+
+func applicationLogger() log.Writer {
+	if App == nil {
+		return nil
+	}
+	if logger := App.MakeLog(); logger != nil {
+		return logger
+	}
+	return nil
+}
+
+// loggingTransport logs each API call and download with its latency when the debug disk option is enabled:
+//
+//	"debug": true,
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (r *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op := operationFromContext(req.Context())
+	if op == nil || !op.debug || op.logger == nil {
+		return r.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := r.base.RoundTrip(req)
+
+	fields := op.fields()
+	fields["method"] = req.Method
+	fields["path"] = req.URL.Path
+	fields["latency"] = time.Since(start).String()
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = resp.StatusCode
+		if message := resp.Header.Get("X-Cld-Error"); message != "" {
+			fields["error"] = message
+		}
+	}
+	op.logger.With(fields).Debug("[Cloudinary] api call")
+	return resp, err
+}
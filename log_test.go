@@ -0,0 +1,52 @@
+package cloudinary
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goravel/framework/contracts/log"
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDebugLogging(t *testing.T) {
+	server := newExplicitServer()
+	defer server.Close()
+
+	var entries []map[string]any
+	mockLog := mockslog.NewWriter(t)
+	mockLog.EXPECT().With(mock.Anything).RunAndReturn(func(fields map[string]any) log.Writer {
+		assert.NotEmpty(t, fields["latency"])
+		delete(fields, "latency")
+		entries = append(entries, fields)
+		return mockLog
+	})
+	mockLog.EXPECT().Debug("[Cloudinary] api call").Times(2)
+	mockLog.EXPECT().Debug("[Cloudinary] operation").Once()
+
+	driver := newServerDriver(t, server.URL, map[string]any{"debug": true}).WithLogger(mockLog)
+	size, err := driver.Size("intro")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1024), size)
+	assert.Equal(t, []map[string]any{
+		{"disk": "cloudinary", "operation": "Size", "method": http.MethodPost, "path": "/v1_1/cloud/image/explicit", "status": http.StatusNotFound, "error": "Resource not found"},
+		{"disk": "cloudinary", "operation": "Size", "method": http.MethodPost, "path": "/v1_1/cloud/video/explicit", "status": http.StatusOK},
+		{"disk": "cloudinary", "operation": "Size"},
+	}, entries)
+}
+
+func TestLogging(t *testing.T) {
+	server := newExplicitServer()
+	defer server.Close()
+
+	// Only the errors that aren't returned are logged without the debug option.
+	mockLog := mockslog.NewWriter(t)
+	mockLog.EXPECT().With(map[string]any{"disk": "cloudinary", "operation": "Url"}).Return(mockLog).Once()
+	mockLog.EXPECT().Warningf("[Cloudinary] url of %s error: %+v", "outro", mock.Anything).Once()
+
+	driver := newServerDriver(t, server.URL, nil).WithLogger(mockLog)
+	_, err := driver.Size("intro")
+	assert.Nil(t, err)
+	assert.Empty(t, driver.Url("outro"))
+}
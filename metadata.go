@@ -0,0 +1,305 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MetadataDateFormat is the layout Cloudinary uses for date metadata values.
+const MetadataDateFormat = "2006-01-02"
+
+var contextEscaper = strings.NewReplacer(`=`, `\=`, `|`, `\|`)
+
+// SetContextMetadata adds the contextual metadata (key=value) to a file, existing keys will be overwritten.
+func (r *Cloudinary) SetContextMetadata(file string, context map[string]string) (err error) {
+	r, op := r.startOperation("SetContextMetadata", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	asset, err := r.getAsset(validPath(file))
+	if err != nil {
+		return err
+	}
+
+	values := api.CldAPIMap{}
+	for key, value := range context {
+		values[contextEscaper.Replace(key)] = contextEscaper.Replace(value)
+	}
+	result, err := r.instance.Upload.AddContext(r.ctx, uploader.AddContextParams{
+		Context:      values,
+		PublicIDs:    api.CldAPIArray{asset.PublicID},
+		Type:         r.deliveryType(),
+		ResourceType: asset.ResourceType,
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("set context", result.Error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetContextMetadata returns the contextual metadata of a file.
+func (r *Cloudinary) GetContextMetadata(file string) (_ map[string]string, err error) {
+	r, op := r.startOperation("GetContextMetadata", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	asset, err := r.getAsset(validPath(file))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	custom, ok := asset.Context["custom"].(map[string]any)
+	if !ok {
+		return result, nil
+	}
+	for key, value := range custom {
+		result[key] = fmt.Sprint(value)
+	}
+	return result, nil
+}
+
+// SetMetadata sets the structured metadata of a file. The values are validated against the
+// metadata field definitions before being sent, an empty string clears the value of a field.
+func (r *Cloudinary) SetMetadata(file string, values map[string]any) (err error) {
+	r, op := r.startOperation("SetMetadata", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	fields, err := r.MetadataFields()
+	if err != nil {
+		return err
+	}
+	params, err := metadataParams(fields, values)
+	if err != nil {
+		return err
+	}
+
+	asset, err := r.getAsset(validPath(file))
+	if err != nil {
+		return err
+	}
+	result, err := r.instance.Upload.UpdateMetadata(r.ctx, uploader.UpdateMetadataParams{
+		PublicIDs:    []string{asset.PublicID},
+		Metadata:     params,
+		Type:         r.deliveryType(),
+		ResourceType: asset.ResourceType,
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("set metadata", errorResp(result.Error)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMetadata returns the structured metadata of a file.
+func (r *Cloudinary) GetMetadata(file string) (_ map[string]any, err error) {
+	r, op := r.startOperation("GetMetadata", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	asset, err := r.getAsset(validPath(file))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(asset.Metadata))
+	for key, value := range asset.Metadata {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// MetadataFields returns all the structured metadata field definitions.
+func (r *Cloudinary) MetadataFields() (_ []metadata.Field, err error) {
+	r, op := r.startOperation("MetadataFields")
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.ListMetadataFields(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("list metadata fields", result.Error); err != nil {
+		return nil, err
+	}
+	return result.MetadataFields, nil
+}
+
+// AddMetadataField creates a structured metadata field definition.
+func (r *Cloudinary) AddMetadataField(field metadata.Field) (err error) {
+	r, op := r.startOperation("AddMetadataField", attribute.String("cloudinary.metadata_field", field.ExternalID))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.AddMetadataField(r.ctx, field)
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("add metadata field", result.Error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteMetadataField deletes a structured metadata field definition.
+func (r *Cloudinary) DeleteMetadataField(externalID string) (err error) {
+	r, op := r.startOperation("DeleteMetadataField", attribute.String("cloudinary.metadata_field", externalID))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.DeleteMetadataField(r.ctx, admin.DeleteMetadataFieldParams{
+		FieldExternalID: externalID,
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("delete metadata field", result.Error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateMetadataDataSource updates the datasource of an enum or set metadata field, entries with
+// an existing external ID are updated and the others are appended.
+func (r *Cloudinary) UpdateMetadataDataSource(externalID string, values ...metadata.DataSourceValue) (err error) {
+	r, op := r.startOperation("UpdateMetadataDataSource", attribute.String("cloudinary.metadata_field", externalID))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.UpdateMetadataFieldDataSource(r.ctx, admin.UpdateMetadataFieldDataSourceParams{
+		FieldExternalID: externalID,
+		DataSource:      metadata.DataSource{Values: values},
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("update metadata datasource", result.Error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteMetadataDataSource deletes the given entries from the datasource of an enum or set metadata field.
+func (r *Cloudinary) DeleteMetadataDataSource(externalID string, entries ...string) (err error) {
+	r, op := r.startOperation("DeleteMetadataDataSource", attribute.String("cloudinary.metadata_field", externalID))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.DeleteDataSourceEntries(r.ctx, admin.DeleteDataSourceEntriesParams{
+		FieldExternalID:    externalID,
+		EntriesExternalIDs: entries,
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.apiError("delete metadata datasource", result.Error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// metadataParams validates the values against the field definitions and encodes them the way
+// the metadata API expects.
+func metadataParams(fields []metadata.Field, values map[string]any) (api.CldAPIMap, error) {
+	definitions := make(map[string]metadata.Field, len(fields))
+	for _, field := range fields {
+		definitions[field.ExternalID] = field
+	}
+
+	params := api.CldAPIMap{}
+	for key, value := range values {
+		field, ok := definitions[key]
+		if !ok {
+			return nil, fmt.Errorf("metadata field %s not found", key)
+		}
+		if value == "" {
+			params[key] = ""
+			continue
+		}
+
+		encoded, err := metadataValue(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for metadata field %s: %w", key, err)
+		}
+		params[key] = encoded
+	}
+	return params, nil
+}
+
+func metadataValue(field metadata.Field, value any) (string, error) {
+	switch field.Type {
+	case metadata.StringFieldType:
+		str, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("expected string, got %T", value)
+		}
+		return contextEscaper.Replace(str), nil
+	case metadata.IntegerFieldType:
+		switch number := value.(type) {
+		case int:
+			return strconv.Itoa(number), nil
+		case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return fmt.Sprint(number), nil
+		case string:
+			if _, err := strconv.ParseInt(number, 10, 64); err != nil {
+				return "", fmt.Errorf("expected integer, got %q", number)
+			}
+			return number, nil
+		default:
+			return "", fmt.Errorf("expected integer, got %T", value)
+		}
+	case metadata.DateFieldType:
+		switch date := value.(type) {
+		case time.Time:
+			return date.Format(MetadataDateFormat), nil
+		case string:
+			if _, err := time.Parse(MetadataDateFormat, date); err != nil {
+				return "", fmt.Errorf("expected date in %s format, got %q", MetadataDateFormat, date)
+			}
+			return date, nil
+		default:
+			return "", fmt.Errorf("expected date, got %T", value)
+		}
+	case metadata.EnumFieldType:
+		entry, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("expected datasource entry, got %T", value)
+		}
+		if !hasDataSourceEntry(field.DataSource, entry) {
+			return "", fmt.Errorf("datasource entry %s not found", entry)
+		}
+		return entry, nil
+	case metadata.SetFieldType:
+		entries, ok := value.([]string)
+		if !ok {
+			return "", fmt.Errorf("expected datasource entries, got %T", value)
+		}
+		for _, entry := range entries {
+			if !hasDataSourceEntry(field.DataSource, entry) {
+				return "", fmt.Errorf("datasource entry %s not found", entry)
+			}
+		}
+		encoded, err := json.Marshal(entries)
+		if err != nil {
+			return "", err
+		}
+		return contextEscaper.Replace(string(encoded)), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %s", field.Type)
+	}
+}
+
+func hasDataSourceEntry(dataSource metadata.DataSource, externalID string) bool {
+	for _, value := range dataSource.Values {
+		if value.ExternalID == externalID && value.State != "inactive" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package cloudinary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataParams(t *testing.T) {
+	fields := []metadata.Field{
+		{ExternalID: "title", Type: metadata.StringFieldType},
+		{ExternalID: "count", Type: metadata.IntegerFieldType},
+		{ExternalID: "published", Type: metadata.DateFieldType},
+		{ExternalID: "color", Type: metadata.EnumFieldType, DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+			{ExternalID: "red", Value: "Red"},
+			{ExternalID: "blue", Value: "Blue", State: "inactive"},
+		}}},
+		{ExternalID: "labels", Type: metadata.SetFieldType, DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+			{ExternalID: "a", Value: "A"},
+			{ExternalID: "b", Value: "B"},
+		}}},
+	}
+
+	tests := []struct {
+		name      string
+		values    map[string]any
+		expect    api.CldAPIMap
+		expectErr string
+	}{
+		{
+			name: "valid values",
+			values: map[string]any{
+				"title":     "a=b|c",
+				"count":     int64(3),
+				"published": time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC),
+				"color":     "red",
+				"labels":    []string{"a", "b"},
+			},
+			expect: api.CldAPIMap{
+				"title":     `a\=b\|c`,
+				"count":     "3",
+				"published": "2024-02-01",
+				"color":     "red",
+				"labels":    `["a","b"]`,
+			},
+		},
+		{
+			name:   "empty value clears the field",
+			values: map[string]any{"count": ""},
+			expect: api.CldAPIMap{"count": ""},
+		},
+		{
+			name:      "unknown field",
+			values:    map[string]any{"missing": "value"},
+			expectErr: "metadata field missing not found",
+		},
+		{
+			name:      "invalid integer",
+			values:    map[string]any{"count": "three"},
+			expectErr: `invalid value for metadata field count: expected integer, got "three"`,
+		},
+		{
+			name:      "invalid date",
+			values:    map[string]any{"published": "01/02/2024"},
+			expectErr: `invalid value for metadata field published: expected date in 2006-01-02 format, got "01/02/2024"`,
+		},
+		{
+			name:      "inactive enum entry",
+			values:    map[string]any{"color": "blue"},
+			expectErr: "invalid value for metadata field color: datasource entry blue not found",
+		},
+		{
+			name:      "invalid set",
+			values:    map[string]any{"labels": "a"},
+			expectErr: "invalid value for metadata field labels: expected datasource entries, got string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params, err := metadataParams(fields, test.values)
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expect, params)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package cloudinary
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+
+	DirectionUpload   = "upload"
+	DirectionDownload = "download"
+)
+
+// Metrics receives the measures of the driver operations, the prometheus package provides an adapter for a
+// Prometheus registry. It's set with WithMetrics or the metrics disk option:
+//
+//	"metrics": cloudinaryprometheus.MustNewMetrics(prometheus.DefaultRegisterer),
+type Metrics interface {
+	// Operation is called when an operation ends, with the number of API calls and downloads it made,
+	// including the ones of the operations it called. The outcome is OutcomeSuccess or OutcomeError.
+	Operation(disk, operation, outcome string, duration time.Duration, apiCalls int)
+	// Bytes is called when an operation uploads or downloads a file, the direction is DirectionUpload
+	// or DirectionDownload.
+	Bytes(disk, operation, direction string, bytes int)
+	// APICall is called after each API call and download, including the retries. The status is 0 when
+	// the call failed without a response.
+	APICall(disk, operation string, status int, duration time.Duration)
+	// RateLimit is called after each Admin API call that returned the rate limit of the account.
+	RateLimit(disk string, limit RateLimit)
+}
+
+// WithMetrics returns a copy of the driver that reports its operations to the metrics instead of the
+// ones of the metrics disk option.
+func (r *Cloudinary) WithMetrics(metrics Metrics) Driver {
+	driver := *r
+	driver.metrics = metrics
+	return &driver
+}
+
+// metricsTransport counts the API calls of the operations and records their status, and reports them to
+// the metrics.
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+func (r *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op := operationFromContext(req.Context())
+	if op == nil {
+		return r.base.RoundTrip(req)
+	}
+
+	op.addAPICall()
+	start := time.Now()
+	resp, err := r.base.RoundTrip(req)
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+	}
+	op.status.Store(int32(status))
+	if op.metrics != nil {
+		op.metrics.APICall(op.disk, op.name, status, time.Since(start))
+	}
+	return resp, err
+}
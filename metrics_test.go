@@ -0,0 +1,95 @@
+package cloudinary
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	server := newExplicitServer()
+	defer server.Close()
+
+	metrics := &recordedMetrics{}
+	driver := newServerDriver(t, server.URL, map[string]any{"metrics": metrics})
+
+	// Exists costs an Explicit probe per resource type until the file is found.
+	assert.True(t, driver.Exists("intro"))
+	_, err := driver.Size("outro")
+	assert.NotNil(t, err)
+	// The API calls of MetadataFields are also counted for SetMetadata, which calls it.
+	assert.NotNil(t, driver.SetMetadata("intro", map[string]any{"rating": 5}))
+	// The operations are reported with the name of the public method that was called.
+	_, err = driver.Archive(ArchiveOptions{Prefixes: []string{"videos"}})
+	assert.Nil(t, err)
+	_, err = driver.PutFile("videos", &File{path: "logo.png"})
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{
+		"Exists success 2",
+		"Size error 3",
+		"MetadataFields error 1",
+		"SetMetadata error 1",
+		"Archive success 0",
+		"MakeDirectory error 1",
+		"PutFile error 1",
+	}, metrics.operations)
+	assert.Equal(t, []string{
+		"Exists 404", "Exists 200",
+		"Size 404", "Size 404", "Size 404",
+		"MetadataFields 404",
+		"MakeDirectory 404",
+	}, metrics.apiCalls)
+}
+
+func TestMetricsRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-FeatureRateLimit-Limit", "500")
+		w.Header().Set("X-FeatureRateLimit-Remaining", "42")
+		_, _ = w.Write([]byte(`{"plan":"Free"}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordedMetrics{}
+	driver := newServerDriver(t, server.URL, nil).WithMetrics(metrics)
+	_, err := driver.Usage()
+	assert.Nil(t, err)
+	assert.Equal(t, []RateLimit{{Limit: 500, Remaining: 42}}, metrics.rateLimits)
+}
+
+type recordedMetrics struct {
+	mu         sync.Mutex
+	operations []string
+	apiCalls   []string
+	bytes      []string
+	rateLimits []RateLimit
+}
+
+func (r *recordedMetrics) Operation(disk, operation, outcome string, duration time.Duration, apiCalls int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = append(r.operations, fmt.Sprintf("%s %s %d", operation, outcome, apiCalls))
+}
+
+func (r *recordedMetrics) Bytes(disk, operation, direction string, bytes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bytes = append(r.bytes, fmt.Sprintf("%s %s %d", operation, direction, bytes))
+}
+
+func (r *recordedMetrics) APICall(disk, operation string, status int, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apiCalls = append(r.apiCalls, fmt.Sprintf("%s %d", operation, status))
+}
+
+func (r *recordedMetrics) RateLimit(disk string, limit RateLimit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rateLimits = append(r.rateLimits, limit)
+}
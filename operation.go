@@ -0,0 +1,118 @@
+package cloudinary
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/goravel/framework/contracts/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// operation is a call of a driver method. It's stored in the context of the driver, so that the API calls
+// made by the method are traced and logged as part of it.
+type operation struct {
+	name    string
+	disk    string
+	start   time.Time
+	span    trace.Span
+	logger  log.Writer
+	debug   bool
+	metrics Metrics
+	// parent is the operation that called this one.
+	parent   *operation
+	apiCalls atomic.Int32
+	// status is the HTTP status of the last API call, as the SDK doesn't return it.
+	status atomic.Int32
+}
+
+type operationKey struct{}
+
+// startOperation starts an operation, and returns a copy of the driver whose API calls belong to it.
+func (r *Cloudinary) startOperation(name string, attributes ...attribute.KeyValue) (*Cloudinary, *operation) {
+	provider := r.tracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	attributes = append(attributes,
+		attribute.String("cloudinary.disk", r.disk),
+		attribute.String("cloudinary.operation", name),
+	)
+	ctx, span := provider.Tracer(tracerName).Start(r.ctx, "cloudinary."+name, trace.WithAttributes(attributes...))
+
+	op := &operation{
+		name:    name,
+		disk:    r.disk,
+		start:   time.Now(),
+		span:    span,
+		logger:  r.logger(),
+		debug:   r.debug,
+		metrics: r.metrics,
+		parent:  operationFromContext(r.ctx),
+	}
+	driver := *r
+	driver.ctx = context.WithValue(ctx, operationKey{}, op)
+	return &driver, op
+}
+
+// operationFromContext returns the operation of an API call, nil when it's made outside an operation.
+func operationFromContext(ctx context.Context) *operation {
+	op, _ := ctx.Value(operationKey{}).(*operation)
+	return op
+}
+
+func (r *operation) end(err error) {
+	if err != nil {
+		r.span.RecordError(err)
+		r.span.SetStatus(codes.Error, err.Error())
+	}
+	r.span.End()
+
+	duration := time.Since(r.start)
+	if r.metrics != nil {
+		outcome := OutcomeSuccess
+		if err != nil {
+			outcome = OutcomeError
+		}
+		r.metrics.Operation(r.disk, r.name, outcome, duration, int(r.apiCalls.Load()))
+	}
+	if r.debug && r.logger != nil {
+		fields := r.fields()
+		fields["latency"] = duration.String()
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		r.logger.With(fields).Debug("[Cloudinary] operation")
+	}
+}
+
+// addAPICall counts an API call of the operation and of the operations that called it.
+func (r *operation) addAPICall() {
+	for op := r; op != nil; op = op.parent {
+		op.apiCalls.Add(1)
+	}
+}
+
+// lastStatus returns the HTTP status of the last API call of the operation, 0 when it failed without a response.
+func (r *operation) lastStatus() int {
+	return int(r.status.Load())
+}
+
+// addBytes reports the bytes uploaded or downloaded by the operation.
+func (r *operation) addBytes(direction string, bytes int) {
+	r.span.SetAttributes(attribute.Int("cloudinary.bytes", bytes), attribute.String("cloudinary.direction", direction))
+	if r.metrics != nil {
+		r.metrics.Bytes(r.disk, r.name, direction, bytes)
+	}
+}
+
+// fields returns the log fields identifying the operation.
+func (r *operation) fields() map[string]any {
+	return map[string]any{
+		"disk":      r.disk,
+		"operation": r.name,
+	}
+}
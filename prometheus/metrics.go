@@ -0,0 +1,110 @@
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/goravel/cloudinary"
+)
+
+// Metrics reports the measures of the driver to a Prometheus registry.
+type Metrics struct {
+	operations        *prometheus.CounterVec
+	operationDuration *prometheus.HistogramVec
+	operationAPICalls *prometheus.HistogramVec
+	bytes             *prometheus.CounterVec
+	apiCalls          *prometheus.CounterVec
+	apiCallDuration   *prometheus.HistogramVec
+	rateLimit         *prometheus.GaugeVec
+	rateLimitLeft     *prometheus.GaugeVec
+}
+
+var _ cloudinary.Metrics = (*Metrics)(nil)
+
+// NewMetrics registers the metrics of the driver to the registerer.
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	metrics := &Metrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cloudinary_operations_total",
+			Help: "Number of driver operations.",
+		}, []string{"disk", "operation", "outcome"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cloudinary_operation_duration_seconds",
+			Help:    "Duration of the driver operations.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"disk", "operation", "outcome"}),
+		operationAPICalls: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cloudinary_operation_api_calls",
+			Help:    "Number of API calls made by a driver operation.",
+			Buckets: []float64{0, 1, 2, 3, 5, 10, 25, 50, 100},
+		}, []string{"disk", "operation"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cloudinary_bytes_total",
+			Help: "Number of bytes uploaded and downloaded by the driver operations.",
+		}, []string{"disk", "operation", "direction"}),
+		apiCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cloudinary_api_calls_total",
+			Help: "Number of API calls and downloads, the status is 0 when there was no response.",
+		}, []string{"disk", "operation", "status"}),
+		apiCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cloudinary_api_call_duration_seconds",
+			Help:    "Duration of the API calls and downloads.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"disk", "operation"}),
+		rateLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cloudinary_rate_limit",
+			Help: "Admin API rate limit of the account.",
+		}, []string{"disk"}),
+		rateLimitLeft: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "cloudinary_rate_limit_remaining",
+			Help: "Admin API calls remaining until the rate limit resets.",
+		}, []string{"disk"}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		metrics.operations,
+		metrics.operationDuration,
+		metrics.operationAPICalls,
+		metrics.bytes,
+		metrics.apiCalls,
+		metrics.apiCallDuration,
+		metrics.rateLimit,
+		metrics.rateLimitLeft,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return metrics, nil
+}
+
+// MustNewMetrics is like NewMetrics, but panics when the metrics can't be registered.
+func MustNewMetrics(registerer prometheus.Registerer) *Metrics {
+	metrics, err := NewMetrics(registerer)
+	if err != nil {
+		panic(err)
+	}
+	return metrics
+}
+
+func (r *Metrics) Operation(disk, operation, outcome string, duration time.Duration, apiCalls int) {
+	r.operations.WithLabelValues(disk, operation, outcome).Inc()
+	r.operationDuration.WithLabelValues(disk, operation, outcome).Observe(duration.Seconds())
+	r.operationAPICalls.WithLabelValues(disk, operation).Observe(float64(apiCalls))
+}
+
+func (r *Metrics) Bytes(disk, operation, direction string, bytes int) {
+	r.bytes.WithLabelValues(disk, operation, direction).Add(float64(bytes))
+}
+
+func (r *Metrics) APICall(disk, operation string, status int, duration time.Duration) {
+	r.apiCalls.WithLabelValues(disk, operation, strconv.Itoa(status)).Inc()
+	r.apiCallDuration.WithLabelValues(disk, operation).Observe(duration.Seconds())
+}
+
+func (r *Metrics) RateLimit(disk string, limit cloudinary.RateLimit) {
+	r.rateLimit.WithLabelValues(disk).Set(float64(limit.Limit))
+	r.rateLimitLeft.WithLabelValues(disk).Set(float64(limit.Remaining))
+}
@@ -0,0 +1,39 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goravel/cloudinary"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	assert.Nil(t, err)
+
+	metrics.Operation("cloudinary", "Exists", cloudinary.OutcomeSuccess, 120*time.Millisecond, 2)
+	metrics.Operation("cloudinary", "Exists", cloudinary.OutcomeError, 80*time.Millisecond, 3)
+	metrics.Bytes("cloudinary", "GetBytes", cloudinary.DirectionDownload, 1024)
+	metrics.Bytes("cloudinary", "GetBytes", cloudinary.DirectionDownload, 512)
+	metrics.APICall("cloudinary", "Exists", 404, 40*time.Millisecond)
+	metrics.APICall("cloudinary", "Exists", 420, 40*time.Millisecond)
+	metrics.RateLimit("cloudinary", cloudinary.RateLimit{Limit: 500, Remaining: 42})
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.operations.WithLabelValues("cloudinary", "Exists", "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.operations.WithLabelValues("cloudinary", "Exists", "error")))
+	assert.Equal(t, float64(1536), testutil.ToFloat64(metrics.bytes.WithLabelValues("cloudinary", "GetBytes", "download")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.apiCalls.WithLabelValues("cloudinary", "Exists", "420")))
+	assert.Equal(t, float64(500), testutil.ToFloat64(metrics.rateLimit.WithLabelValues("cloudinary")))
+	assert.Equal(t, float64(42), testutil.ToFloat64(metrics.rateLimitLeft.WithLabelValues("cloudinary")))
+	assert.Equal(t, 11, testutil.CollectAndCount(registry))
+
+	// The metrics can only be registered once.
+	_, err = NewMetrics(registry)
+	assert.NotNil(t, err)
+	assert.Panics(t, func() { MustNewMetrics(registry) })
+}
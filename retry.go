@@ -0,0 +1,229 @@
+package cloudinary
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/goravel/framework/contracts/config"
+	"github.com/spf13/cast"
+)
+
+// retryBodyLimit is the size of the largest request body that is buffered to be retried, the larger
+// uploads are sent once.
+const retryBodyLimit = 32 << 20
+
+// defaultRetryStatuses are the statuses of the responses worth retrying: 420 is the rate limit
+// status of Cloudinary, the others are transient server errors.
+var defaultRetryStatuses = []int{420, 429, 500, 502, 503, 504}
+
+// RetryPolicy configures how the failed requests of a disk are retried:
+//
+//	"retry": map[string]any{
+//		"attempts":  3,
+//		"delay":     500 * time.Millisecond,
+//		"jitter":    0.2,
+//		"max_delay": 30 * time.Second,
+//		"statuses":  []int{420, 429, 500, 502, 503, 504},
+//	},
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts of a request, 1 disables the retries.
+	Attempts int
+	// Delay is the delay before the first retry, it doubles after each attempt.
+	Delay time.Duration
+	// Jitter is the fraction of the delay randomly added to it, so that clients don't retry at the same time.
+	Jitter float64
+	// MaxDelay caps the delay before a retry, including the one asked by the Retry-After header.
+	MaxDelay time.Duration
+	// Statuses are the response statuses that are retried, network errors are always retried.
+	Statuses []int
+}
+
+func retryPolicy(config config.Config, disk string) RetryPolicy {
+	return RetryPolicy{
+		Attempts: config.GetInt(fmt.Sprintf("filesystems.disks.%s.retry.attempts", disk), 1),
+		Delay:    config.GetDuration(fmt.Sprintf("filesystems.disks.%s.retry.delay", disk), 500*time.Millisecond),
+		Jitter:   cast.ToFloat64(config.Get(fmt.Sprintf("filesystems.disks.%s.retry.jitter", disk), 0.2)),
+		MaxDelay: config.GetDuration(fmt.Sprintf("filesystems.disks.%s.retry.max_delay", disk), 30*time.Second),
+		Statuses: cast.ToIntSlice(config.Get(fmt.Sprintf("filesystems.disks.%s.retry.statuses", disk), defaultRetryStatuses)),
+	}
+}
+
+// backoff returns the delay before the retry following the attempt.
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	// The delay saturates instead of overflowing when the attempts get large.
+	delay := time.Duration(math.MaxInt64)
+	if shift := attempt - 1; shift < 63 && r.Delay <= delay>>shift {
+		delay = r.Delay << shift
+	}
+	if r.Jitter > 0 {
+		jitter := rand.Float64() * r.Jitter * float64(delay)
+		if jitter < float64(math.MaxInt64-delay) {
+			delay += time.Duration(jitter)
+		} else {
+			delay = math.MaxInt64
+		}
+	}
+	return delay
+}
+
+// retryTransport retries the requests of the SDK clients and of the downloads.
+type retryTransport struct {
+	policy RetryPolicy
+	base   http.RoundTripper
+}
+
+func (r *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.policy.Attempts <= 1 {
+		return r.base.RoundTrip(req)
+	}
+
+	attempts := r.policy.Attempts
+	upload := isUpload(req)
+	retryable, decided := req.Context().Value(uploadRetryKey{}).(bool)
+	if upload && decided && !retryable {
+		return r.base.RoundTrip(req)
+	}
+
+	// The body is buffered to be sent again, as the SDK streams the uploads through a pipe. A body larger
+	// than the limit is streamed instead, and isn't retried.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(req.Body, retryBodyLimit+1))
+		if err != nil {
+			req.Body.Close()
+			return nil, err
+		}
+		if len(body) > retryBodyLimit {
+			req.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+			return r.base.RoundTrip(req)
+		}
+		req.Body.Close()
+	}
+	if upload && !decided && !idempotentUpload(req.Header.Get("Content-Type"), body) {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := r.base.RoundTrip(req)
+		if attempt >= attempts || !r.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := r.policy.backoff(attempt)
+		if resp != nil {
+			delay = max(delay, retryAfter(resp))
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if r.policy.MaxDelay > 0 {
+			delay = min(delay, r.policy.MaxDelay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	return slices.Contains(r.policy.Statuses, resp.StatusCode)
+}
+
+// httpClient returns the client used to download files, it retries like the API clients.
+func (r *Cloudinary) httpClient() *http.Client {
+	return &http.Client{Transport: r.instance.Upload.Client.Transport}
+}
+
+// uploadRetryKey is the context key of whether an upload can be retried, it's decided from the upload
+// parameters before the SDK builds the request.
+type uploadRetryKey struct{}
+
+// withUploadRetry returns the context of an upload with whether it can be retried.
+func withUploadRetry(ctx context.Context, params uploader.UploadParams) context.Context {
+	retryable := params.PublicID != "" ||
+		params.UseFilename != nil && *params.UseFilename && params.UniqueFilename != nil && !*params.UniqueFilename
+	return context.WithValue(ctx, uploadRetryKey{}, retryable)
+}
+
+func isUpload(req *http.Request) bool {
+	return req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/upload")
+}
+
+// idempotentUpload reports whether uploading the body again is safe, which is the case when the public ID
+// doesn't depend on the attempt: uploading again overwrites the same file, or returns it when overwrite is false.
+func idempotentUpload(contentType string, body []byte) bool {
+	params, err := uploadParams(contentType, body)
+	if err != nil {
+		return false
+	}
+	if params.Get("public_id") != "" {
+		return true
+	}
+	useFilename, _ := strconv.ParseBool(params.Get("use_filename"))
+	uniqueFilename, err := strconv.ParseBool(params.Get("unique_filename"))
+	return useFilename && err == nil && !uniqueFilename
+}
+
+// uploadParams returns the parameters of an upload body, without the file.
+func uploadParams(contentType string, body []byte) (url.Values, error) {
+	mediaType, mediaParams, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		return url.ParseQuery(string(body))
+	}
+
+	params := url.Values{}
+	reader := multipart.NewReader(bytes.NewReader(body), mediaParams["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return params, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FileName() != "" {
+			continue
+		}
+		value, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		params.Add(part.FormName(), string(value))
+	}
+}
+
+// retryAfter returns the delay asked by the Retry-After header of a response.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,299 @@
+package cloudinary
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransport(t *testing.T) {
+	policy := RetryPolicy{Attempts: 3, Delay: time.Millisecond, Jitter: 0.2, Statuses: defaultRetryStatuses}
+	tests := []struct {
+		name        string
+		statuses    []int
+		request     func(url string) *http.Request
+		expectCalls int32
+		expect      int
+	}{
+		{
+			name:     "rate limited",
+			statuses: []int{420, 420, 200},
+			request: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, url+"/cloud/resources/image", nil)
+				return req
+			},
+			expectCalls: 3,
+			expect:      200,
+		},
+		{
+			name:     "exhausted",
+			statuses: []int{503, 503, 503, 200},
+			request: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, url+"/cloud/resources/image", nil)
+				return req
+			},
+			expectCalls: 3,
+			expect:      503,
+		},
+		{
+			name:     "not retryable",
+			statuses: []int{400, 200},
+			request: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, url+"/cloud/resources/image", nil)
+				return req
+			},
+			expectCalls: 1,
+			expect:      400,
+		},
+		{
+			name:     "form body",
+			statuses: []int{500, 200},
+			request: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, url+"/cloud/image/explicit", strings.NewReader("public_id=avatar"))
+				return req
+			},
+			expectCalls: 2,
+			expect:      200,
+		},
+		{
+			name:     "upload with a random public id",
+			statuses: []int{500, 200},
+			request: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, url+"/cloud/auto/upload", strings.NewReader("file=https://example.com/avatar.png"))
+				return req
+			},
+			expectCalls: 1,
+			expect:      500,
+		},
+		{
+			name:     "upload with a public id",
+			statuses: []int{500, 200},
+			request: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, url+"/cloud/auto/upload", strings.NewReader("file=https://example.com/avatar.png&public_id=avatar"))
+				return req
+			},
+			expectCalls: 2,
+			expect:      200,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int32
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				w.WriteHeader(test.statuses[calls.Add(1)-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &retryTransport{policy: policy, base: http.DefaultTransport}}
+			resp, err := client.Do(test.request(server.URL))
+			assert.Nil(t, err)
+			assert.Nil(t, resp.Body.Close())
+			assert.Equal(t, test.expect, resp.StatusCode)
+			assert.Equal(t, test.expectCalls, calls.Load())
+			for _, body := range bodies {
+				assert.Equal(t, bodies[0], body)
+			}
+		})
+	}
+}
+
+func TestRetryTransportCanceled(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.Nil(t, err)
+
+	client := &http.Client{Transport: &retryTransport{
+		policy: RetryPolicy{Attempts: 5, Delay: time.Hour, Statuses: defaultRetryStatuses},
+		base:   http.DefaultTransport,
+	}}
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotentUpload(t *testing.T) {
+	multipartBody := func(fields map[string]string) (string, []byte) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for key, value := range fields {
+			assert.Nil(t, writer.WriteField(key, value))
+		}
+		part, err := writer.CreateFormFile("file", "avatar.png")
+		assert.Nil(t, err)
+		_, err = part.Write([]byte("public_id=avatar"))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+		return writer.FormDataContentType(), body.Bytes()
+	}
+
+	contentType, body := multipartBody(map[string]string{"public_id": "avatars/goravel"})
+	assert.True(t, idempotentUpload(contentType, body))
+	contentType, body = multipartBody(map[string]string{"folder": "avatars", "use_filename": "true", "unique_filename": "false"})
+	assert.True(t, idempotentUpload(contentType, body))
+	contentType, body = multipartBody(map[string]string{"folder": "avatars", "use_filename": "true"})
+	assert.False(t, idempotentUpload(contentType, body))
+	// The file content isn't taken for a parameter.
+	contentType, body = multipartBody(map[string]string{"folder": "avatars"})
+	assert.False(t, idempotentUpload(contentType, body))
+
+	assert.True(t, idempotentUpload("", []byte("file=https://example.com/avatar.png&public_id=avatar")))
+	assert.False(t, idempotentUpload("", []byte("file=https://example.com/avatar.png")))
+}
+
+func TestRetryDriver(t *testing.T) {
+	var pings, uploads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/upload") {
+			uploads.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if pings.Add(1) == 1 {
+			w.WriteHeader(420)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	driver := newTestDriver(t, map[string]any{
+		"cloud":          "cloud",
+		"key":            "key",
+		"secret":         "secret",
+		"upload_prefix":  server.URL,
+		"retry.attempts": 3,
+		"retry.delay":    time.Millisecond,
+	})
+
+	result, err := driver.instance.Admin.Ping(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", result.Status)
+	assert.Equal(t, int32(2), pings.Load())
+
+	file := filepath.Join(t.TempDir(), "avatar.png")
+	assert.Nil(t, os.WriteFile(file, []byte("avatar"), 0644))
+	_, _ = driver.instance.Upload.Upload(context.Background(), file, uploader.UploadParams{})
+	assert.Equal(t, int32(1), uploads.Load())
+	_, _ = driver.instance.Upload.Upload(context.Background(), file, uploader.UploadParams{PublicID: "avatar"})
+	assert.Equal(t, int32(4), uploads.Load())
+}
+
+func TestRetryTransportUnbuffered(t *testing.T) {
+	var calls atomic.Int32
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sizes = append(sizes, len(body))
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		policy: RetryPolicy{Attempts: 3, Delay: time.Millisecond, Statuses: defaultRetryStatuses},
+		base:   http.DefaultTransport,
+	}}
+
+	// A body larger than the limit is streamed once.
+	large := strings.Repeat("a", retryBodyLimit+1)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/cloud/image/explicit", io.NopCloser(strings.NewReader("public_id=avatar&"+large)))
+	assert.Nil(t, err)
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []int{len("public_id=avatar&") + len(large)}, sizes)
+
+	// An upload decided not retryable from its parameters isn't buffered, whatever its body.
+	ctx := withUploadRetry(context.Background(), uploader.UploadParams{Folder: "avatars"})
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/cloud/auto/upload", strings.NewReader("public_id=avatar"))
+	assert.Nil(t, err)
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, int32(2), calls.Load())
+
+	ctx = withUploadRetry(context.Background(), uploader.UploadParams{PublicID: "avatar"})
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/cloud/auto/upload", strings.NewReader("file=avatar"))
+	assert.Nil(t, err)
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestRetryTransportMaxDelay(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(420)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		policy: RetryPolicy{Attempts: 2, Delay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Statuses: defaultRetryStatuses},
+		base:   http.DefaultTransport,
+	}}
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The delay after a network error is capped too.
+	var failures atomic.Int32
+	client = &http.Client{Transport: &retryTransport{
+		policy: RetryPolicy{Attempts: 3, Delay: time.Hour, MaxDelay: 10 * time.Millisecond, Statuses: defaultRetryStatuses},
+		base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			failures.Add(1)
+			return nil, errors.New("connection reset")
+		}),
+	}}
+	start = time.Now()
+	_, err = client.Get(server.URL)
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, int32(3), failures.Load())
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The backoff saturates instead of overflowing.
+	policy := RetryPolicy{Delay: time.Second, Jitter: 0.5}
+	assert.Equal(t, time.Duration(math.MaxInt64), policy.backoff(100))
+	assert.Greater(t, policy.backoff(40), time.Duration(0))
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (r roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r(req)
+}
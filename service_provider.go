@@ -1,7 +1,12 @@
 package cloudinary
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/goravel/framework/contracts/binding"
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/foundation"
 	"golang.org/x/net/context"
 )
@@ -29,11 +34,102 @@ func (r *ServiceProvider) Relationship() binding.Relationship {
 
 func (r *ServiceProvider) Register(app foundation.Application) {
 	App = app
+	drivers.reset()
 
 	app.BindWith(Binding, func(app foundation.Application, parameters map[string]any) (any, error) {
 		return NewCloudinary(context.Background(), app.MakeConfig(), parameters["disk"].(string))
 	})
 }
 func (r *ServiceProvider) Boot(app foundation.Application) {
+	app.Commands([]console.Command{
+		NewCheckCommand(app.MakeConfig()),
+	})
+	checkDisks(app.MakeConfig())
+
+	// The router is only resolved when it's needed, as resolving it prints an error in the applications
+	// without the route service provider.
+	upload := routePrefixed(app.MakeConfig(), "upload")
+	webhook := routePrefixed(app.MakeConfig(), "webhook")
+	if !upload && !webhook {
+		return
+	}
+	router := app.MakeRoute()
+	if router == nil {
+		return
+	}
+
+	if upload {
+		if err := registerUploadRoutes(app.MakeConfig(), router); err != nil {
+			logBootError("register upload routes", err)
+		}
+	}
+	if webhook {
+		if events := app.MakeEvent(); events != nil {
+			if err := registerWebhookRoutes(app.MakeConfig(), events, router); err != nil {
+				logBootError("register webhook routes", err)
+			}
+		}
+	}
+}
+
+// routePrefixed reports whether a disk configures the prefix of the upload or webhook endpoint.
+func routePrefixed(config config.Config, endpoint string) bool {
+	disks, _ := config.Get("filesystems.disks").(map[string]any)
+	for disk := range disks {
+		if config.GetString(fmt.Sprintf("filesystems.disks.%s.%s.prefix", disk, endpoint)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func logBootError(operation string, err error) {
+	if logger := applicationLogger(); logger != nil {
+		logger.With(map[string]any{"operation": operation}).Errorf("[Cloudinary] %s error: %+v", operation, err)
+	}
+}
+
+// Disk returns the driver of a disk, it's made the first time and shared by the next calls.
+func Disk(disk string) (Driver, error) {
+	driver, err := drivers.get(disk)
+	if err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+var drivers = &driverCache{drivers: make(map[string]*Cloudinary)}
+
+// driverCache keeps the drivers of the disks, as they can be shared and are costly to make.
+type driverCache struct {
+	mu      sync.Mutex
+	drivers map[string]*Cloudinary
+}
+
+func (r *driverCache) get(disk string) (*Cloudinary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if driver, ok := r.drivers[disk]; ok {
+		return driver, nil
+	}
+	if App == nil {
+		return nil, ErrNotRegistered
+	}
+	instance, err := App.MakeWith(Binding, map[string]any{"disk": disk})
+	if err != nil {
+		return nil, err
+	}
+	driver, ok := instance.(*Cloudinary)
+	if !ok {
+		return nil, fmt.Errorf("cloudinary: the binding of disk %s isn't a cloudinary driver: %T", disk, instance)
+	}
+	r.drivers[disk] = driver
+	return driver, nil
+}
 
+func (r *driverCache) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.drivers = make(map[string]*Cloudinary)
 }
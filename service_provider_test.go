@@ -0,0 +1,76 @@
+package cloudinary
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goravel/framework/contracts/foundation"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDisk(t *testing.T) {
+	defer func(app foundation.Application) { App = app }(App)
+	drivers.reset()
+
+	App = nil
+	driver, err := Disk("cloudinary")
+	assert.ErrorIs(t, err, ErrNotRegistered)
+	assert.Nil(t, driver)
+
+	mockApp := mocksfoundation.NewApplication(t)
+	mockApp.On("BindWith", Binding, mock.Anything).Once()
+	(&ServiceProvider{}).Register(mockApp)
+
+	cloudinary := newTestDriver(t, map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"})
+	mockApp.On("MakeWith", Binding, map[string]any{"disk": "cloudinary"}).Return(cloudinary, nil).Once()
+	mockApp.On("MakeWith", Binding, map[string]any{"disk": "broken"}).Return(nil, errors.New("cloudinary config not found for disk broken")).Once()
+
+	// The driver is made once.
+	for range 2 {
+		driver, err = Disk("cloudinary")
+		assert.Nil(t, err)
+		assert.Same(t, cloudinary, driver)
+	}
+	driver, err = Disk("broken")
+	assert.EqualError(t, err, "cloudinary config not found for disk broken")
+	assert.Nil(t, driver)
+
+	// Registering the provider again forgets the drivers of the previous application.
+	mockApp.On("BindWith", Binding, mock.Anything).Once()
+	(&ServiceProvider{}).Register(mockApp)
+	mockApp.On("MakeWith", Binding, map[string]any{"disk": "cloudinary"}).Return(cloudinary, nil).Once()
+	_, err = Disk("cloudinary")
+	assert.Nil(t, err)
+}
+
+func TestBootRoutes(t *testing.T) {
+	mockConfig := mocksconfig.NewConfig(t)
+	mockConfig.On("Get", "filesystems.disks").Return(map[string]any{
+		"cloudinary": map[string]any{"driver": "custom"},
+		"local":      map[string]any{"driver": "local"},
+	})
+	for _, disk := range []string{"cloudinary", "local"} {
+		mockConfig.On("GetString", "filesystems.disks."+disk+".upload.prefix").Return("")
+		mockConfig.On("GetString", "filesystems.disks."+disk+".webhook.prefix").Return("")
+	}
+
+	// Without an upload or webhook prefix, the router isn't resolved.
+	mockApp := mocksfoundation.NewApplication(t)
+	mockApp.On("MakeConfig").Return(mockConfig)
+	mockApp.On("Commands", mock.Anything).Once()
+	(&ServiceProvider{}).Boot(mockApp)
+
+	mockConfig = mocksconfig.NewConfig(t)
+	mockConfig.On("Get", "filesystems.disks").Return(map[string]any{"cloudinary": map[string]any{"driver": "custom"}})
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.upload.prefix").Return("cloudinary/upload")
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.webhook.prefix").Return("")
+
+	mockApp = mocksfoundation.NewApplication(t)
+	mockApp.On("MakeConfig").Return(mockConfig)
+	mockApp.On("Commands", mock.Anything).Once()
+	mockApp.On("MakeRoute").Return(nil).Once()
+	(&ServiceProvider{}).Boot(mockApp)
+}
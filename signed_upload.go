@@ -0,0 +1,153 @@
+package cloudinary
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SignedUploadOptions restricts what the client is allowed to upload with the signed parameters.
+type SignedUploadOptions struct {
+	// PublicID is the name of the file in the folder, a random one is generated by Cloudinary if empty.
+	PublicID string
+	// ResourceType is the resource type of the upload endpoint, defaults to "auto".
+	ResourceType string
+	// AllowedFormats is the list of file formats Cloudinary accepts, e.g. []string{"jpg", "png"}.
+	AllowedFormats []string
+	// MaxFileSize is the maximum file size in bytes. Cloudinary doesn't enforce it for signed uploads,
+	// so it's returned to the client and checked again by VerifyUpload, which deletes a larger file.
+	MaxFileSize int64
+	// Eager is the list of transformations generated right after the upload, e.g. "c_fill,w_300,h_300".
+	Eager []string
+	// Tags is the list of tags added to the uploaded file.
+	Tags []string
+}
+
+// SignedUpload is the set of parameters the client sends to Cloudinary with the file.
+type SignedUpload struct {
+	URL            string `json:"url"`
+	CloudName      string `json:"cloud_name"`
+	APIKey         string `json:"api_key"`
+	Timestamp      int64  `json:"timestamp"`
+	Signature      string `json:"signature"`
+	Type           string `json:"type,omitempty"`
+	Folder         string `json:"folder,omitempty"`
+	PublicID       string `json:"public_id,omitempty"`
+	AllowedFormats string `json:"allowed_formats,omitempty"`
+	MaxFileSize    int64  `json:"max_file_size,omitempty"`
+	Eager          string `json:"eager,omitempty"`
+	Tags           string `json:"tags,omitempty"`
+}
+
+// UploadResponse is the part of the Cloudinary upload response needed to verify a direct upload.
+type UploadResponse struct {
+	PublicID     string `json:"public_id"`
+	Version      int64  `json:"version"`
+	Signature    string `json:"signature"`
+	ResourceType string `json:"resource_type"`
+	Bytes        int64  `json:"bytes"`
+	Format       string `json:"format"`
+}
+
+// SignUpload returns the signed parameters the client needs to upload a file to the given
+// directory straight to Cloudinary.
+func (r *Cloudinary) SignUpload(path string, options SignedUploadOptions) (*SignedUpload, error) {
+	cloud := r.instance.Config.Cloud
+	if cloud.APISecret == "" {
+		return nil, fmt.Errorf("cloudinary secret not found for disk %s", r.disk)
+	}
+
+	resourceType := options.ResourceType
+	if resourceType == "" {
+		resourceType = "auto"
+	}
+	upload := &SignedUpload{
+		URL:            fmt.Sprintf("%s/%s/%s/upload", api.BaseURL(r.instance.Config.API.UploadPrefix, ""), cloud.CloudName, resourceType),
+		CloudName:      cloud.CloudName,
+		APIKey:         cloud.APIKey,
+		Timestamp:      time.Now().Unix(),
+		Folder:         rootPath(r.root, path),
+		PublicID:       options.PublicID,
+		AllowedFormats: strings.Join(options.AllowedFormats, ","),
+		MaxFileSize:    options.MaxFileSize,
+		Eager:          strings.Join(options.Eager, "|"),
+		Tags:           strings.Join(options.Tags, ","),
+	}
+	if deliveryType := r.deliveryType(); deliveryType != string(api.Upload) {
+		upload.Type = deliveryType
+	}
+
+	params := url.Values{}
+	params.Set("timestamp", strconv.FormatInt(upload.Timestamp, 10))
+	for key, value := range map[string]string{
+		"type":            upload.Type,
+		"folder":          upload.Folder,
+		"public_id":       upload.PublicID,
+		"allowed_formats": upload.AllowedFormats,
+		"eager":           upload.Eager,
+		"tags":            upload.Tags,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	signature, err := api.SignParametersUsingAlgoAndVersion(params, cloud.APISecret, cloud.GetSignatureAlgorithm(), cloud.GetSignatureVersion())
+	if err != nil {
+		return nil, err
+	}
+	upload.Signature = signature
+
+	return upload, nil
+}
+
+// VerifyUpload verifies the signature of a direct upload response, and checks the uploaded file
+// against the options the upload was signed with. The file is deleted when it doesn't pass the checks,
+// as Cloudinary has already stored it.
+func (r *Cloudinary) VerifyUpload(response UploadResponse, options SignedUploadOptions) (err error) {
+	r, op := r.startOperation("VerifyUpload", attribute.String("cloudinary.path", response.PublicID))
+	defer func() { op.end(err) }()
+
+	cloud := r.instance.Config.Cloud
+	expected, err := sign(fmt.Sprintf("public_id=%s&version=%d", response.PublicID, response.Version), cloud.APISecret, cloud.GetSignatureAlgorithm())
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expected), []byte(response.Signature)) {
+		return fmt.Errorf("invalid upload signature for %s", response.PublicID)
+	}
+
+	var invalid error
+	if options.MaxFileSize > 0 && response.Bytes > options.MaxFileSize {
+		invalid = fmt.Errorf("uploaded file %s exceeds the max file size: %d > %d", response.PublicID, response.Bytes, options.MaxFileSize)
+	} else if len(options.AllowedFormats) > 0 && !slices.Contains(options.AllowedFormats, response.Format) {
+		invalid = fmt.Errorf("uploaded file %s has a disallowed format: %s", response.PublicID, response.Format)
+	}
+	if invalid == nil {
+		return nil
+	}
+
+	resourceType := options.ResourceType
+	if resourceType == "" || resourceType == api.Auto {
+		resourceType = response.ResourceType
+	}
+	result, err := r.instance.Upload.Destroy(r.ctx, uploader.DestroyParams{
+		PublicID:     response.PublicID,
+		Type:         r.deliveryType(),
+		Invalidate:   api.Bool(true),
+		ResourceType: resourceType,
+	})
+	if err != nil {
+		return errors.Join(invalid, err)
+	}
+	return errors.Join(invalid, r.apiError("delete file", result.Error))
+}
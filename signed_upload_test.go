@@ -0,0 +1,111 @@
+package cloudinary
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignUpload(t *testing.T) {
+	mockConfig := &mocksconfig.Config{}
+	mockDiskConfig(mockConfig, "cloudinary", map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"})
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.delivery_type").Return("")
+
+	driver, err := NewCloudinary(context.Background(), mockConfig, "cloudinary")
+	assert.Nil(t, err)
+
+	options := SignedUploadOptions{
+		PublicID:       "avatar",
+		AllowedFormats: []string{"jpg", "png"},
+		MaxFileSize:    1024,
+		Eager:          []string{"c_fill,w_100,h_100", "w_300"},
+	}
+	upload, err := driver.SignUpload("./avatars/", options)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://api.cloudinary.com/v1_1/cloud/auto/upload", upload.URL)
+	assert.Equal(t, "cloud", upload.CloudName)
+	assert.Equal(t, "key", upload.APIKey)
+	assert.Equal(t, "avatars", upload.Folder)
+	assert.Equal(t, "jpg,png", upload.AllowedFormats)
+	assert.Equal(t, "c_fill,w_100,h_100|w_300", upload.Eager)
+	assert.Equal(t, int64(1024), upload.MaxFileSize)
+
+	payload := fmt.Sprintf("allowed_formats=jpg,png&eager=c_fill,w_100,h_100|w_300&folder=avatars&public_id=avatar&timestamp=%d", upload.Timestamp)
+	sum := sha1.Sum([]byte(payload + "secret"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), upload.Signature)
+
+	upload, err = driver.WithDeliveryType("authenticated").SignUpload("documents", SignedUploadOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "authenticated", upload.Type)
+	sum = sha1.Sum([]byte(fmt.Sprintf("folder=documents&timestamp=%d&type=authenticatedsecret", upload.Timestamp)))
+	assert.Equal(t, hex.EncodeToString(sum[:]), upload.Signature)
+}
+
+func TestVerifyUpload(t *testing.T) {
+	var destroyed []string
+	var missing bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(body))
+		destroyed = append(destroyed, fmt.Sprintf("%s %s %s", r.URL.Path, values.Get("public_id"), values.Get("type")))
+		if missing {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"Resource not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":"ok"}`))
+	}))
+	defer server.Close()
+
+	driver := newServerDriver(t, server.URL, nil)
+	options := SignedUploadOptions{AllowedFormats: []string{"jpg", "png"}, MaxFileSize: 1024}
+	sum := sha1.Sum([]byte("public_id=avatars/avatar&version=1700000000secret"))
+	response := UploadResponse{
+		PublicID:     "avatars/avatar",
+		Version:      1700000000,
+		Signature:    hex.EncodeToString(sum[:]),
+		ResourceType: "image",
+		Bytes:        512,
+		Format:       "png",
+	}
+	assert.Nil(t, driver.VerifyUpload(response, options))
+	assert.Empty(t, destroyed)
+
+	response.Format = "gif"
+	assert.EqualError(t, driver.VerifyUpload(response, options), "uploaded file avatars/avatar has a disallowed format: gif")
+
+	response.Format = "png"
+	response.Bytes = 2048
+	assert.EqualError(t, driver.WithDeliveryType("authenticated").VerifyUpload(response, options), "uploaded file avatars/avatar exceeds the max file size: 2048 > 1024")
+
+	// The file that doesn't pass the checks is deleted.
+	assert.Equal(t, []string{
+		"/v1_1/cloud/image/destroy avatars/avatar upload",
+		"/v1_1/cloud/image/destroy avatars/avatar authenticated",
+	}, destroyed)
+
+	// The status of the delete is known to the operation.
+	missing = true
+	err := driver.VerifyUpload(response, options)
+	assert.ErrorContains(t, err, "uploaded file avatars/avatar exceeds the max file size: 2048 > 1024")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// The delete uses the context of the call.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, driver.VerifyUploadContext(canceled, response, options), context.Canceled)
+	assert.Len(t, destroyed, 3)
+
+	response.Signature = "forged"
+	assert.EqualError(t, driver.VerifyUpload(response, options), "invalid upload signature for avatars/avatar")
+	assert.Len(t, destroyed, 3)
+}
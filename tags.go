@@ -0,0 +1,142 @@
+package cloudinary
+
+import (
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AddTags adds a tag to the given files.
+func (r *Cloudinary) AddTags(tag string, file ...string) (err error) {
+	r, op := r.startOperation("AddTags", attribute.String("cloudinary.tag", tag), attribute.StringSlice("cloudinary.paths", file))
+	defer func() { op.end(err) }()
+
+	publicIDs := rootPaths(r.root, file)
+	for _, assetType := range assetTypes {
+		result, err := r.instance.Upload.AddTag(r.ctx, uploader.AddTagParams{
+			Tag:          tag,
+			PublicIDs:    publicIDs,
+			Type:         r.deliveryType(),
+			ResourceType: string(assetType),
+		})
+		if err != nil {
+			return err
+		}
+		if err := r.apiError("add tags", result.Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveTags removes a tag from the given files.
+func (r *Cloudinary) RemoveTags(tag string, file ...string) (err error) {
+	r, op := r.startOperation("RemoveTags", attribute.String("cloudinary.tag", tag), attribute.StringSlice("cloudinary.paths", file))
+	defer func() { op.end(err) }()
+
+	publicIDs := rootPaths(r.root, file)
+	for _, assetType := range assetTypes {
+		result, err := r.instance.Upload.RemoveTag(r.ctx, uploader.RemoveTagParams{
+			Tag:          tag,
+			PublicIDs:    publicIDs,
+			Type:         r.deliveryType(),
+			ResourceType: string(assetType),
+		})
+		if err != nil {
+			return err
+		}
+		if err := r.apiError("remove tags", result.Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceTags replaces all the existing tags of the given files with a tag.
+func (r *Cloudinary) ReplaceTags(tag string, file ...string) (err error) {
+	r, op := r.startOperation("ReplaceTags", attribute.String("cloudinary.tag", tag), attribute.StringSlice("cloudinary.paths", file))
+	defer func() { op.end(err) }()
+
+	publicIDs := rootPaths(r.root, file)
+	for _, assetType := range assetTypes {
+		result, err := r.instance.Upload.ReplaceTag(r.ctx, uploader.ReplaceTagParams{
+			Tag:          tag,
+			PublicIDs:    publicIDs,
+			Type:         r.deliveryType(),
+			ResourceType: string(assetType),
+		})
+		if err != nil {
+			return err
+		}
+		if err := r.apiError("replace tags", result.Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearTags removes all the tags from the given files.
+func (r *Cloudinary) ClearTags(file ...string) (err error) {
+	r, op := r.startOperation("ClearTags", attribute.StringSlice("cloudinary.paths", file))
+	defer func() { op.end(err) }()
+
+	publicIDs := rootPaths(r.root, file)
+	for _, assetType := range assetTypes {
+		result, err := r.instance.Upload.RemoveAllTags(r.ctx, uploader.RemoveAllTagsParams{
+			PublicIDs:    publicIDs,
+			Type:         r.deliveryType(),
+			ResourceType: string(assetType),
+		})
+		if err != nil {
+			return err
+		}
+		if err := r.apiError("clear tags", result.Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilesByTag returns all the files with the given tag.
+func (r *Cloudinary) FilesByTag(tag string) (_ []string, err error) {
+	r, op := r.startOperation("FilesByTag", attribute.String("cloudinary.tag", tag))
+	defer func() { op.end(err) }()
+
+	var result []string
+	for _, assetType := range assetTypes {
+		nextCursor := ""
+		for {
+			if err := r.ctx.Err(); err != nil {
+				return nil, err
+			}
+			response, err := r.instance.Admin.AssetsByTag(r.ctx, admin.AssetsByTagParams{
+				Tag:        tag,
+				AssetType:  assetType,
+				MaxResults: 500,
+				NextCursor: nextCursor,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := r.apiError("files by tag", response.Error); err != nil {
+				return nil, err
+			}
+
+			// The files of all the delivery types have the tag, only the ones of the disk are kept.
+			for _, asset := range response.Assets {
+				if asset.Type != r.deliveryType() {
+					continue
+				}
+				if file, ok := relativePath(r.root, asset.PublicID); ok {
+					result = append(result, file)
+				}
+			}
+
+			nextCursor = response.NextCursor
+			if nextCursor == "" {
+				break
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,34 @@
+package cloudinary
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesByTagDeliveryType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/v1_1/cloud/resources/image/tags/logo") {
+			_, _ = w.Write([]byte(`{"resources":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"resources":[
+			{"public_id":"goravel/logo","resource_type":"image","type":"upload"},
+			{"public_id":"goravel/private","resource_type":"image","type":"private"},
+			{"public_id":"other/logo","resource_type":"image","type":"upload"}
+		]}`))
+	}))
+	defer server.Close()
+
+	driver := newServerDriver(t, server.URL, map[string]any{"root": "goravel"})
+	files, err := driver.FilesByTag("logo")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"logo"}, files)
+
+	files, err = driver.WithDeliveryType("private").FilesByTag("logo")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"private"}, files)
+}
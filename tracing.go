@@ -0,0 +1,75 @@
+package cloudinary
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/goravel/cloudinary"
+
+// WithTracerProvider returns a copy of the driver that traces its operations with the provider instead of
+// the global one, which doesn't record anything unless the application sets it.
+func (r *Cloudinary) WithTracerProvider(provider trace.TracerProvider) Driver {
+	driver := *r
+	driver.tracerProvider = provider
+	return &driver
+}
+
+// setResourceType records the resource type of the file the operation is about.
+func (r *Cloudinary) setResourceType(resourceType string) {
+	trace.SpanFromContext(r.ctx).SetAttributes(attribute.String("cloudinary.resource_type", resourceType))
+}
+
+// setBytes records the number of bytes the operation uploaded or downloaded.
+func (r *Cloudinary) setBytes(direction string, bytes int) {
+	if op := operationFromContext(r.ctx); op != nil {
+		op.addBytes(direction, bytes)
+	}
+}
+
+// tracingTransport traces each API call and download, including the retries. The spans are created by the
+// provider of the operation span they belong to, so the calls made outside an operation aren't traced.
+type tracingTransport struct {
+	disk string
+	base http.RoundTripper
+}
+
+func (r *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	parent := trace.SpanFromContext(req.Context())
+	ctx, span := parent.TracerProvider().Tracer(tracerName).Start(req.Context(), "cloudinary.http "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("cloudinary.disk", r.disk),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+	if req.ContentLength > 0 {
+		span.SetAttributes(attribute.Int64("http.request.body.size", req.ContentLength))
+	}
+
+	resp, err := r.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.ContentLength >= 0 {
+		span.SetAttributes(attribute.Int64("http.response.body.size", resp.ContentLength))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		// The deliveries and most API calls explain the error in the X-Cld-Error header.
+		if message := resp.Header.Get("X-Cld-Error"); message != "" {
+			span.SetAttributes(attribute.String("cloudinary.error", message))
+		}
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+package cloudinary
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	server := newExplicitServer()
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	driver := newServerDriver(t, server.URL, nil).
+		WithTracerProvider(provider).
+		WithContextDriver(ctx)
+
+	size, err := driver.Size("intro")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1024), size)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 4)
+	image, video, operation := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "cloudinary.Size", operation.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), operation.Parent.SpanID())
+	assert.Equal(t, codes.Unset, operation.Status.Code)
+	assert.Subset(t, operation.Attributes, []attribute.KeyValue{
+		attribute.String("cloudinary.disk", "cloudinary"),
+		attribute.String("cloudinary.operation", "Size"),
+		attribute.String("cloudinary.path", "intro"),
+		attribute.String("cloudinary.resource_type", "video"),
+	})
+
+	// Each Explicit probe is an API call of the operation.
+	for _, span := range []tracetest.SpanStub{image, video} {
+		assert.Equal(t, "cloudinary.http POST", span.Name)
+		assert.Equal(t, operation.SpanContext.SpanID(), span.Parent.SpanID())
+	}
+	assert.Equal(t, codes.Error, image.Status.Code)
+	assert.Subset(t, image.Attributes, []attribute.KeyValue{
+		attribute.String("url.path", "/v1_1/cloud/image/explicit"),
+		attribute.Int("http.response.status_code", http.StatusNotFound),
+		attribute.String("cloudinary.error", "Resource not found"),
+	})
+	assert.Equal(t, codes.Unset, video.Status.Code)
+	assert.Contains(t, video.Attributes, attribute.String("url.path", "/v1_1/cloud/video/explicit"))
+
+	exporter.Reset()
+	_, err = driver.LastModified("outro")
+	assert.NotNil(t, err)
+
+	spans = exporter.GetSpans()
+	assert.Len(t, spans, 4)
+	operation = spans[3]
+	assert.Equal(t, "cloudinary.LastModified", operation.Name)
+	assert.Equal(t, codes.Error, operation.Status.Code)
+	assert.Equal(t, err.Error(), operation.Status.Description)
+}
+
+func TestTracingDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"public_id":"intro","resource_type":"image","type":"upload","bytes":1024}`))
+	}))
+	defer server.Close()
+
+	// Without a provider, the spans go to the global provider, which doesn't record them by default.
+	driver := newServerDriver(t, server.URL, nil)
+	size, err := driver.Size("intro")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1024), size)
+}
+
+// newServerDriver returns a driver whose API calls are sent to the server.
+func newServerDriver(t *testing.T, server string, options map[string]any) *Cloudinary {
+	diskOptions := map[string]any{"cloud": "cloud", "key": "key", "secret": "secret", "upload_prefix": server}
+	for key, value := range options {
+		diskOptions[key] = value
+	}
+	mockConfig := mocksconfig.NewConfig(t)
+	mockDiskConfig(mockConfig, "cloudinary", diskOptions)
+	mockConfig.On("GetString", "filesystems.disks.cloudinary.delivery_type").Return("").Maybe()
+	driver, err := NewCloudinary(context.Background(), mockConfig, "cloudinary")
+	assert.Nil(t, err)
+	return driver
+}
+
+// newExplicitServer returns a server whose only file is the "intro" video.
+func newExplicitServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The SDK doesn't set the content type of the form.
+		body, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.URL.Path, "/v1_1/cloud/video/") && strings.Contains(string(body), "public_id=intro&") {
+			_, _ = w.Write([]byte(`{"public_id":"intro","resource_type":"video","type":"upload","bytes":1024}`))
+			return
+		}
+		w.Header().Set("X-Cld-Error", "Resource not found")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"message":"Resource not found"}}`))
+	}))
+}
@@ -0,0 +1,146 @@
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/route"
+	"github.com/spf13/cast"
+)
+
+// UploadController returns signed upload parameters for the folders allowed by the disk config:
+//
+//	"upload": map[string]any{
+//		"prefix":     "cloudinary/upload",
+//		"middleware": []http.Middleware{middleware.Auth()},
+//		"folders": map[string]any{
+//			"avatars": map[string]any{
+//				"allowed_formats": []string{"jpg", "png"},
+//				"max_file_size":   1024 * 1024,
+//				"eager":           []string{"c_fill,w_300,h_300"},
+//			},
+//		},
+//	},
+type UploadController struct {
+	driver   *Cloudinary
+	policies map[string]SignedUploadOptions
+}
+
+func NewUploadController(config config.Config, disk string) (*UploadController, error) {
+	driver, err := NewCloudinary(context.Background(), config, disk)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]SignedUploadOptions)
+	folders, _ := config.Get(fmt.Sprintf("filesystems.disks.%s.upload.folders", disk)).(map[string]any)
+	for folder, value := range folders {
+		policy, err := cast.ToStringMapE(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upload policy for folder %s of disk %s: %w", folder, disk, err)
+		}
+		policies[validPath(folder)] = SignedUploadOptions{
+			ResourceType:   cast.ToString(policy["resource_type"]),
+			AllowedFormats: cast.ToStringSlice(policy["allowed_formats"]),
+			MaxFileSize:    cast.ToInt64(policy["max_file_size"]),
+			Eager:          cast.ToStringSlice(policy["eager"]),
+			Tags:           cast.ToStringSlice(policy["tags"]),
+		}
+	}
+
+	return &UploadController{
+		driver:   driver,
+		policies: policies,
+	}, nil
+}
+
+// Sign returns the signed upload parameters for the requested "path" and optional "name".
+func (r *UploadController) Sign(ctx http.Context) http.Response {
+	path := ctx.Request().Input("path")
+	name := ctx.Request().Input("name")
+	if err := uploadPath(path, name); err != nil {
+		return ctx.Response().Json(http.StatusUnprocessableEntity, http.Json{
+			"message": err.Error(),
+		})
+	}
+
+	path = validPath(path)
+	options, ok := r.policy(path)
+	if !ok {
+		return ctx.Response().Json(http.StatusForbidden, http.Json{
+			"message": fmt.Sprintf("uploading to %s is not allowed", path),
+		})
+	}
+	options.PublicID = name
+
+	upload, err := r.driver.SignUpload(path, options)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, http.Json{
+			"message": err.Error(),
+		})
+	}
+	return ctx.Response().Success().Json(upload)
+}
+
+// uploadPath checks the requested path and name, a ".." segment could escape the folder of the policy.
+func uploadPath(path, name string) error {
+	if err := checkPath(path); err != nil {
+		return err
+	}
+	if name != "" {
+		if err := checkFile(name); err != nil {
+			return err
+		}
+	}
+	file := path
+	if name != "" {
+		file += "/" + name
+	}
+	for _, segment := range strings.Split(file, "/") {
+		if segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidPath, file)
+		}
+	}
+	return nil
+}
+
+// policy returns the options of the deepest configured folder that contains the path.
+func (r *UploadController) policy(path string) (SignedUploadOptions, bool) {
+	folders := make([]string, 0, len(r.policies))
+	for folder := range r.policies {
+		folders = append(folders, folder)
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return len(folders[i]) > len(folders[j])
+	})
+
+	for _, folder := range folders {
+		if path == folder || strings.HasPrefix(path, folder+"/") {
+			return r.policies[folder], true
+		}
+	}
+	return SignedUploadOptions{}, false
+}
+
+// registerUploadRoutes registers the signed upload endpoint of every disk that configures an upload prefix.
+func registerUploadRoutes(config config.Config, router route.Route) error {
+	disks, _ := config.Get("filesystems.disks").(map[string]any)
+	for disk := range disks {
+		prefix := config.GetString(fmt.Sprintf("filesystems.disks.%s.upload.prefix", disk))
+		if prefix == "" {
+			continue
+		}
+
+		controller, err := NewUploadController(config, disk)
+		if err != nil {
+			return err
+		}
+		middleware, _ := config.Get(fmt.Sprintf("filesystems.disks.%s.upload.middleware", disk)).([]http.Middleware)
+		router.Middleware(middleware...).Post(prefix, controller.Sign)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cloudinary
+
+import (
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockshttp "github.com/goravel/framework/mocks/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUploadControllerSign(t *testing.T) {
+	mockConfig := mocksconfig.NewConfig(t)
+	mockDiskConfig(mockConfig, "cloudinary", map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"})
+	mockConfig.EXPECT().GetString("filesystems.disks.cloudinary.delivery_type").Return("")
+	mockConfig.EXPECT().Get("filesystems.disks.cloudinary.upload.folders").Return(map[string]any{
+		"users": map[string]any{
+			"max_file_size": 2048,
+		},
+		"/users/avatars/": map[string]any{
+			"allowed_formats": []string{"jpg", "png"},
+			"max_file_size":   1024,
+			"eager":           []any{"c_fill,w_100,h_100"},
+		},
+	})
+
+	controller, err := NewUploadController(mockConfig, "cloudinary")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name   string
+		path   string
+		file   string
+		assert func(code int, obj any)
+	}{
+		{
+			name: "deepest folder",
+			path: "users/avatars/1",
+			file: "avatar",
+			assert: func(code int, obj any) {
+				upload := obj.(*SignedUpload)
+				assert.Equal(t, "users/avatars/1", upload.Folder)
+				assert.Equal(t, "avatar", upload.PublicID)
+				assert.Equal(t, "jpg,png", upload.AllowedFormats)
+				assert.Equal(t, int64(1024), upload.MaxFileSize)
+				assert.Equal(t, "c_fill,w_100,h_100", upload.Eager)
+				assert.NotEmpty(t, upload.Signature)
+			},
+		},
+		{
+			name: "parent folder",
+			path: "./users/1/",
+			assert: func(code int, obj any) {
+				upload := obj.(*SignedUpload)
+				assert.Equal(t, "users/1", upload.Folder)
+				assert.Empty(t, upload.AllowedFormats)
+				assert.Equal(t, int64(2048), upload.MaxFileSize)
+			},
+		},
+		{
+			name: "forbidden folder",
+			path: "usersfake/1",
+			assert: func(code int, obj any) {
+				assert.Equal(t, contractshttp.StatusForbidden, code)
+				assert.Equal(t, contractshttp.Json{"message": "uploading to usersfake/1 is not allowed"}, obj)
+			},
+		},
+		{
+			name: "parent segment",
+			path: "users/../admins",
+			assert: func(code int, obj any) {
+				assert.Equal(t, contractshttp.StatusUnprocessableEntity, code)
+				assert.Equal(t, contractshttp.Json{"message": `cloudinary: invalid path: "users/../admins"`}, obj)
+			},
+		},
+		{
+			name: "parent segment in name",
+			path: "users",
+			file: "../admins/avatar",
+			assert: func(code int, obj any) {
+				assert.Equal(t, contractshttp.StatusUnprocessableEntity, code)
+				assert.Equal(t, contractshttp.Json{"message": `cloudinary: invalid path: "users/../admins/avatar"`}, obj)
+			},
+		},
+		{
+			name: "invalid path",
+			path: "users/1?x=1",
+			assert: func(code int, obj any) {
+				assert.Equal(t, contractshttp.StatusUnprocessableEntity, code)
+				assert.Equal(t, contractshttp.Json{"message": `cloudinary: invalid path: "users/1?x=1"`}, obj)
+			},
+		},
+		{
+			name: "invalid name",
+			path: "users",
+			file: " / ",
+			assert: func(code int, obj any) {
+				assert.Equal(t, contractshttp.StatusUnprocessableEntity, code)
+				assert.Equal(t, contractshttp.Json{"message": `cloudinary: invalid path: " / "`}, obj)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockContext := mockshttp.NewContext(t)
+			mockRequest := mockshttp.NewContextRequest(t)
+			mockResponse := mockshttp.NewContextResponse(t)
+			mockContext.EXPECT().Request().Return(mockRequest)
+			mockContext.EXPECT().Response().Return(mockResponse)
+			mockRequest.EXPECT().Input("path").Return(test.path)
+			mockRequest.EXPECT().Input("name").Return(test.file)
+
+			var code int
+			var obj any
+			mockAbortableResponse := mockshttp.NewAbortableResponse(t)
+			mockSuccess := mockshttp.NewResponseStatus(t)
+			mockResponse.EXPECT().Success().Return(mockSuccess).Maybe()
+			mockSuccess.EXPECT().Json(mock.Anything).RunAndReturn(func(o any) contractshttp.AbortableResponse {
+				code, obj = contractshttp.StatusOK, o
+				return mockAbortableResponse
+			}).Maybe()
+			mockResponse.EXPECT().Json(mock.Anything, mock.Anything).RunAndReturn(func(c int, o any) contractshttp.AbortableResponse {
+				code, obj = c, o
+				return mockAbortableResponse
+			}).Maybe()
+
+			assert.Equal(t, mockAbortableResponse, controller.Sign(mockContext))
+			test.assert(code, obj)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+)
+
+// Usage is the usage of the account of a disk, reported by Cloudinary with a delay of a few hours.
+type Usage struct {
+	Plan            string      `json:"plan"`
+	LastUpdated     string      `json:"last_updated"`
+	Storage         UsageMetric `json:"storage"`
+	Bandwidth       UsageMetric `json:"bandwidth"`
+	Transformations UsageMetric `json:"transformations"`
+	Objects         UsageMetric `json:"objects"`
+	Credits         UsageMetric `json:"credits"`
+	// Requests is the number of delivery requests.
+	Requests         int64 `json:"requests"`
+	Resources        int64 `json:"resources"`
+	DerivedResources int64 `json:"derived_resources"`
+}
+
+// UsageMetric is the usage of a metric, in bytes for the storage and the bandwidth. Limit and
+// UsedPercent are zero for the metrics that are only limited by the credits of the plan.
+type UsageMetric struct {
+	Usage        float64 `json:"usage"`
+	Limit        float64 `json:"limit"`
+	UsedPercent  float64 `json:"used_percent"`
+	CreditsUsage float64 `json:"credits_usage"`
+}
+
+// Usage returns the usage of the account of the disk.
+func (r *Cloudinary) Usage() (_ *Usage, err error) {
+	r, op := r.startOperation("Usage")
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.Usage(r.ctx, admin.UsageParams{})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("usage", result.Error); err != nil {
+		return nil, err
+	}
+
+	// The SDK doesn't decode the limit of the credits, so the raw response is decoded instead.
+	raw, err := json.Marshal(result.Response)
+	if err != nil {
+		return nil, err
+	}
+	var usage Usage
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// RateLimit is the Admin API rate limit of an account, as of its last Admin API call.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Known reports whether an Admin API call returned the rate limit yet.
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// RateLimit returns the Admin API rate limit of the account of the disk, which is shared by the disks
+// of the account.
+func (r *Cloudinary) RateLimit() RateLimit {
+	return rateLimits.get(r.instance.Config.Cloud.CloudName, r.instance.Config.Cloud.APIKey).current()
+}
+
+// rateLimits tracks the rate limit of the accounts, which can be shared by several disks.
+var rateLimits = &rateLimitRegistry{limits: make(map[string]*rateLimitState)}
+
+type rateLimitRegistry struct {
+	mu     sync.Mutex
+	limits map[string]*rateLimitState
+}
+
+func (r *rateLimitRegistry) get(cloudName, apiKey string) *rateLimitState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := cloudName + "/" + apiKey
+	if _, ok := r.limits[key]; !ok {
+		r.limits[key] = &rateLimitState{}
+	}
+	return r.limits[key]
+}
+
+type rateLimitState struct {
+	mu    sync.Mutex
+	limit RateLimit
+}
+
+func (r *rateLimitState) current() RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.limit
+}
+
+// update reads the rate limit from the X-FeatureRateLimit headers of an Admin API response, and reports
+// whether the response had them.
+func (r *rateLimitState) update(header http.Header) bool {
+	limit, err := strconv.Atoi(header.Get("X-FeatureRateLimit-Limit"))
+	if err != nil {
+		return false
+	}
+	remaining, _ := strconv.Atoi(header.Get("X-FeatureRateLimit-Remaining"))
+	reset, _ := http.ParseTime(header.Get("X-FeatureRateLimit-Reset"))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limit = RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+	return true
+}
+
+// delay returns how long to wait before the next call, so that the remaining calls are spread until the
+// reset once fewer than threshold calls remain.
+func (r *rateLimitState) delay(threshold int, now time.Time) time.Duration {
+	limit := r.current()
+	if !limit.Known() || limit.Remaining >= threshold || !now.Before(limit.Reset) {
+		return 0
+	}
+	return limit.Reset.Sub(now) / time.Duration(limit.Remaining+1)
+}
+
+// rateLimitTransport tracks the rate limit of the Admin API calls, and slows them down when it's close:
+//
+//	"rate_limit": map[string]any{
+//		"threshold": 50,
+//	},
+type rateLimitTransport struct {
+	state     *rateLimitState
+	threshold int
+	base      http.RoundTripper
+}
+
+func (r *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if delay := r.state.delay(r.threshold, time.Now()); delay > 0 {
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.state.update(resp.Header) {
+		if op := operationFromContext(req.Context()); op != nil && op.metrics != nil {
+			op.metrics.RateLimit(op.disk, r.state.current())
+		}
+	}
+	return resp, nil
+}
+
+// sleep waits for the duration, unless the context is done first.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package cloudinary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	reset := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1_1/usage-cloud/usage", r.URL.Path)
+		w.Header().Set("X-FeatureRateLimit-Limit", "500")
+		w.Header().Set("X-FeatureRateLimit-Remaining", "498")
+		w.Header().Set("X-FeatureRateLimit-Reset", reset.Format(http.TimeFormat))
+		_, _ = w.Write([]byte(`{
+			"plan": "Free",
+			"last_updated": "2026-10-17",
+			"transformations": {"usage": 120, "credits_usage": 0.12},
+			"objects": {"usage": 42},
+			"bandwidth": {"usage": 1048576, "credits_usage": 0.01},
+			"storage": {"usage": 2097152, "credits_usage": 0.02},
+			"credits": {"usage": 0.15, "limit": 25, "used_percent": 0.6},
+			"requests": 1000,
+			"resources": 42,
+			"derived_resources": 7
+		}`))
+	}))
+	defer server.Close()
+
+	driver := newTestDriver(t, map[string]any{"cloud": "usage-cloud", "key": "key", "secret": "secret", "upload_prefix": server.URL})
+	assert.False(t, driver.RateLimit().Known())
+
+	usage, err := driver.Usage()
+	assert.Nil(t, err)
+	assert.Equal(t, "Free", usage.Plan)
+	assert.Equal(t, UsageMetric{Usage: 2097152, CreditsUsage: 0.02}, usage.Storage)
+	assert.Equal(t, UsageMetric{Usage: 1048576, CreditsUsage: 0.01}, usage.Bandwidth)
+	assert.Equal(t, UsageMetric{Usage: 120, CreditsUsage: 0.12}, usage.Transformations)
+	assert.Equal(t, UsageMetric{Usage: 0.15, Limit: 25, UsedPercent: 0.6}, usage.Credits)
+	assert.Equal(t, int64(42), usage.Resources)
+
+	// The copies of the driver share the rate limit.
+	limit := driver.WithContextDriver(context.Background()).RateLimit()
+	assert.Equal(t, RateLimit{Limit: 500, Remaining: 498, Reset: reset}, RateLimit{Limit: limit.Limit, Remaining: limit.Remaining, Reset: limit.Reset.UTC()})
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		limit  RateLimit
+		expect time.Duration
+	}{
+		{name: "unknown", expect: 0},
+		{name: "above the threshold", limit: RateLimit{Limit: 500, Remaining: 100, Reset: now.Add(time.Minute)}, expect: 0},
+		{name: "below the threshold", limit: RateLimit{Limit: 500, Remaining: 9, Reset: now.Add(time.Minute)}, expect: 6 * time.Second},
+		{name: "exhausted", limit: RateLimit{Limit: 500, Remaining: 0, Reset: now.Add(time.Minute)}, expect: time.Minute},
+		{name: "reset", limit: RateLimit{Limit: 500, Remaining: 0, Reset: now.Add(-time.Minute)}, expect: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := &rateLimitState{limit: test.limit}
+			assert.Equal(t, test.expect, state.delay(50, now))
+		})
+	}
+}
+
+func TestRateLimitTransportCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request should wait for the reset")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.Nil(t, err)
+
+	client := &http.Client{Transport: &rateLimitTransport{
+		state:     &rateLimitState{limit: RateLimit{Limit: 500, Remaining: 0, Reset: time.Now().Add(time.Hour)}},
+		threshold: 50,
+		base:      http.DefaultTransport,
+	}}
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
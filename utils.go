@@ -1,7 +1,12 @@
 package cloudinary
 
 import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -10,17 +15,27 @@ import (
 
 // GetRawContent retrieves the raw content of a file from the provided URL.
 func GetRawContent(url string) ([]byte, error) {
+	return getRawContent(context.Background(), http.DefaultClient, url)
+}
+
+func getRawContent(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching raw content: %w", err)
+	}
 	// Make an HTTP GET request to fetch the file data
-	resp, err := http.Get(url)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching raw content: %w", err)
 	}
 
-	rawContent, err := io.ReadAll(resp.Body)
-	if err != nil {
+	defer resp.Body.Close()
+	if err := responseError("get file", resp); err != nil {
 		return nil, err
 	}
-	if err := resp.Body.Close(); err != nil {
+
+	rawContent, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return nil, err
 	}
 
@@ -34,3 +49,58 @@ func validPath(path string) string {
 	realPath = strings.TrimSuffix(realPath, string(filepath.Separator))
 	return realPath
 }
+
+// rootPath returns the path of a file in the root folder of the disk.
+func rootPath(root, path string) string {
+	root = validPath(root)
+	path = validPath(path)
+	if root == "" {
+		return path
+	}
+	if path == "" {
+		return root
+	}
+	return root + "/" + path
+}
+
+// rootPaths returns the paths of files in the root folder of the disk.
+func rootPaths(root string, paths []string) []string {
+	realPaths := make([]string, len(paths))
+	for i, path := range paths {
+		realPaths[i] = rootPath(root, path)
+	}
+	return realPaths
+}
+
+// relativePath returns the path of a file relative to the root folder of the disk, and false if the
+// file is outside of it.
+func relativePath(root, path string) (string, bool) {
+	root = validPath(root)
+	if root == "" {
+		return path, true
+	}
+	if path == root {
+		return "", true
+	}
+	if !strings.HasPrefix(path, root+"/") {
+		return path, false
+	}
+	return strings.TrimPrefix(path, root+"/"), true
+}
+
+// sign returns the hex encoded digest of the content followed by the secret, which is how
+// Cloudinary signs its responses and notifications.
+func sign(content, secret, algo string) (string, error) {
+	var hashFunc hash.Hash
+	switch algo {
+	case "sha1":
+		hashFunc = sha1.New()
+	case "sha256":
+		hashFunc = sha256.New()
+	default:
+		return "", fmt.Errorf("unsupported signature algorithm: %s", algo)
+	}
+	hashFunc.Write([]byte(content + secret))
+
+	return hex.EncodeToString(hashFunc.Sum(nil)), nil
+}
@@ -0,0 +1,37 @@
+package cloudinary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootPath(t *testing.T) {
+	assert.Equal(t, "avatars/goravel.png", rootPath("", "./avatars/goravel.png"))
+	assert.Equal(t, "blog/avatars/goravel.png", rootPath("blog", "/avatars/goravel.png"))
+	assert.Equal(t, "apps/blog/avatars", rootPath("./apps/blog/", "avatars/"))
+	assert.Equal(t, "blog", rootPath("blog", ""))
+	assert.Equal(t, []string{"blog/a", "blog/b"}, rootPaths("blog", []string{"a", "./b"}))
+}
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		root   string
+		path   string
+		expect string
+		inRoot bool
+	}{
+		{root: "", path: "avatars/goravel", expect: "avatars/goravel", inRoot: true},
+		{root: "blog", path: "blog/avatars/goravel", expect: "avatars/goravel", inRoot: true},
+		{root: "/apps/blog/", path: "apps/blog/avatars", expect: "avatars", inRoot: true},
+		{root: "blog", path: "blog", expect: "", inRoot: true},
+		{root: "blog", path: "blogs/avatars/goravel", expect: "blogs/avatars/goravel", inRoot: false},
+		{root: "blog", path: "shop/avatars/goravel", expect: "shop/avatars/goravel", inRoot: false},
+	}
+
+	for _, test := range tests {
+		path, inRoot := relativePath(test.root, test.path)
+		assert.Equal(t, test.expect, path, test.path)
+		assert.Equal(t, test.inRoot, inRoot, test.path)
+	}
+}
@@ -0,0 +1,159 @@
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// VideoClip is the part of a video to keep, End and Duration are exclusive and both optional.
+type VideoClip struct {
+	Start    float64
+	End      float64
+	Duration float64
+}
+
+// VideoInfo is the metadata of a video.
+type VideoInfo struct {
+	PublicID  string      `json:"public_id"`
+	Format    string      `json:"format"`
+	Width     int         `json:"width"`
+	Height    int         `json:"height"`
+	Bytes     int64       `json:"bytes"`
+	Duration  float64     `json:"duration"`
+	BitRate   int64       `json:"bit_rate"`
+	FrameRate float64     `json:"frame_rate"`
+	Video     VideoStream `json:"video"`
+	Audio     AudioStream `json:"audio"`
+}
+
+// VideoStream is the video stream of a video.
+type VideoStream struct {
+	Codec       string `json:"codec"`
+	Profile     string `json:"profile"`
+	PixelFormat string `json:"pix_format"`
+	BitRate     string `json:"bit_rate"`
+}
+
+// AudioStream is the audio stream of a video.
+type AudioStream struct {
+	Codec         string `json:"codec"`
+	BitRate       string `json:"bit_rate"`
+	Frequency     int    `json:"frequency"`
+	Channels      int    `json:"channels"`
+	ChannelLayout string `json:"channel_layout"`
+}
+
+// VideoPosterUrl returns the url of the video frame at the offset in seconds, as a jpg if format is empty.
+func (r *Cloudinary) VideoPosterUrl(file string, offset float64, format string) (string, error) {
+	if format == "" {
+		format = "jpg"
+	}
+	return r.videoUrl(file, "so_"+formatSeconds(offset), format)
+}
+
+// VideoStreamUrl returns the adaptive streaming manifest url of a video for a streaming profile,
+// the format is "m3u8" for HLS or "mpd" for DASH.
+func (r *Cloudinary) VideoStreamUrl(file, profile, format string) (string, error) {
+	if format != "m3u8" && format != "mpd" {
+		return "", fmt.Errorf("unsupported streaming format: %s", format)
+	}
+	if profile == "" {
+		profile = "auto"
+	}
+	return r.videoUrl(file, "sp_"+profile, format)
+}
+
+// VideoClipUrl returns the url of a trimmed video, the original format is kept if format is empty.
+func (r *Cloudinary) VideoClipUrl(file string, clip VideoClip, format string) (string, error) {
+	if clip.End > 0 && clip.Duration > 0 {
+		return "", fmt.Errorf("video clip end and duration are exclusive")
+	}
+	if clip.End > 0 && clip.End <= clip.Start {
+		return "", fmt.Errorf("video clip end must be after its start")
+	}
+
+	transformation := "so_" + formatSeconds(clip.Start)
+	if clip.End > 0 {
+		transformation += ",eo_" + formatSeconds(clip.End)
+	}
+	if clip.Duration > 0 {
+		transformation += ",du_" + formatSeconds(clip.Duration)
+	}
+	return r.videoUrl(file, transformation, format)
+}
+
+// VideoAudioUrl returns the url of the audio track of a video, as a mp3 if format is empty.
+func (r *Cloudinary) VideoAudioUrl(file, format string) (string, error) {
+	if format == "" {
+		format = "mp3"
+	}
+	return r.videoUrl(file, "", format)
+}
+
+// VideoTranscodeUrl returns the url of a video transcoded to the format, and to the codec if it isn't empty.
+func (r *Cloudinary) VideoTranscodeUrl(file, format, codec string) (string, error) {
+	if format == "" {
+		return "", fmt.Errorf("no transcoding format given for %s", file)
+	}
+
+	transformation := ""
+	if codec != "" {
+		transformation = "vc_" + codec
+	}
+	return r.videoUrl(file, transformation, format)
+}
+
+// VideoInfo returns the metadata of a video.
+func (r *Cloudinary) VideoInfo(file string) (_ *VideoInfo, err error) {
+	r, op := r.startOperation("VideoInfo", attribute.String("cloudinary.path", file))
+	defer func() { op.end(err) }()
+
+	result, err := r.instance.Admin.Asset(r.ctx, admin.AssetParams{
+		AssetType:     api.Video,
+		DeliveryType:  api.DeliveryType(r.deliveryType()),
+		PublicID:      rootPath(r.root, file),
+		MediaMetadata: api.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.apiError("video info", result.Error); err != nil {
+		return nil, err
+	}
+
+	// The SDK only exposes the media metadata as a map, so the raw response is decoded instead.
+	raw, err := json.Marshal(result.Response)
+	if err != nil {
+		return nil, err
+	}
+	var info VideoInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return nil, err
+	}
+	info.PublicID, _ = relativePath(r.root, info.PublicID)
+	return &info, nil
+}
+
+func (r *Cloudinary) videoUrl(file, transformation, format string) (string, error) {
+	asset, err := r.instance.Video(rootPath(r.root, file))
+	if err != nil {
+		return "", err
+	}
+	asset.DeliveryType = api.DeliveryType(r.deliveryType())
+	// Authenticated and private videos can only be delivered with a signature.
+	asset.Config.URL.SignURL = asset.Config.URL.SignURL || asset.DeliveryType != api.Upload
+	asset.Transformation = transformation
+	if format != "" {
+		asset.PublicID += "." + format
+	}
+	return asset.String()
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', -1, 64)
+}
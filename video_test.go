@@ -0,0 +1,62 @@
+package cloudinary
+
+import (
+	"context"
+	"testing"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoUrls(t *testing.T) {
+	mockConfig := mocksconfig.NewConfig(t)
+	mockDiskConfig(mockConfig, "cloudinary", map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"})
+	mockConfig.EXPECT().GetString("filesystems.disks.cloudinary.delivery_type").Return("")
+
+	driver, err := NewCloudinary(context.Background(), mockConfig, "cloudinary")
+	assert.Nil(t, err)
+	driver.instance.Config.URL.Analytics = false
+
+	url, err := driver.VideoPosterUrl("./videos/intro", 2.5, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/so_2.5/v1/videos/intro.jpg", url)
+
+	url, err = driver.VideoStreamUrl("videos/intro", "hd", "m3u8")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/sp_hd/v1/videos/intro.m3u8", url)
+
+	url, err = driver.VideoStreamUrl("videos/intro", "", "mpd")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/sp_auto/v1/videos/intro.mpd", url)
+
+	_, err = driver.VideoStreamUrl("videos/intro", "hd", "mp4")
+	assert.EqualError(t, err, "unsupported streaming format: mp4")
+
+	url, err = driver.VideoClipUrl("videos/intro", VideoClip{Start: 1, End: 3.5}, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/so_1,eo_3.5/v1/videos/intro", url)
+
+	url, err = driver.VideoClipUrl("videos/intro", VideoClip{Duration: 10}, "webm")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/so_0,du_10/v1/videos/intro.webm", url)
+
+	_, err = driver.VideoClipUrl("videos/intro", VideoClip{Start: 3, End: 1}, "")
+	assert.EqualError(t, err, "video clip end must be after its start")
+
+	url, err = driver.VideoAudioUrl("videos/intro", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/v1/videos/intro.mp3", url)
+
+	url, err = driver.VideoTranscodeUrl("videos/intro", "mp4", "h265")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/vc_h265/v1/videos/intro.mp4", url)
+
+	url, err = driver.WithDeliveryType("authenticated").VideoPosterUrl("videos/intro", 0, "")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^https://res\.cloudinary\.com/cloud/video/authenticated/s--[\w-]{8}--/so_0/v1/videos/intro\.jpg$`, url)
+
+	driver.root = "blog"
+	url, err = driver.VideoAudioUrl("videos/intro", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://res.cloudinary.com/cloud/video/upload/v1/blog/videos/intro.mp3", url)
+}
@@ -0,0 +1,262 @@
+package cloudinary
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/route"
+	frameworkerrors "github.com/goravel/framework/errors"
+)
+
+// Notification is the part shared by all the Cloudinary notifications.
+type Notification struct {
+	NotificationType string `json:"notification_type"`
+	RequestID        string `json:"request_id"`
+}
+
+// UploadNotification is sent when a file is uploaded.
+type UploadNotification struct {
+	Notification
+	AssetID          string    `json:"asset_id"`
+	PublicID         string    `json:"public_id"`
+	Version          int64     `json:"version"`
+	Width            int       `json:"width"`
+	Height           int       `json:"height"`
+	Format           string    `json:"format"`
+	ResourceType     string    `json:"resource_type"`
+	Type             string    `json:"type"`
+	CreatedAt        time.Time `json:"created_at"`
+	Tags             []string  `json:"tags"`
+	Bytes            int64     `json:"bytes"`
+	Etag             string    `json:"etag"`
+	URL              string    `json:"url"`
+	SecureURL        string    `json:"secure_url"`
+	OriginalFilename string    `json:"original_filename"`
+}
+
+// DeleteNotification is sent when files are deleted.
+type DeleteNotification struct {
+	Notification
+	Resources []DeletedResource `json:"resources"`
+}
+
+// DeletedResource is a single file of a DeleteNotification.
+type DeletedResource struct {
+	AssetID      string `json:"asset_id"`
+	PublicID     string `json:"public_id"`
+	Version      int64  `json:"version"`
+	ResourceType string `json:"resource_type"`
+	Type         string `json:"type"`
+}
+
+// ModerationNotification is sent when the moderation status of a file changes.
+type ModerationNotification struct {
+	Notification
+	AssetID          string `json:"asset_id"`
+	PublicID         string `json:"public_id"`
+	Version          int64  `json:"version"`
+	ModerationStatus string `json:"moderation_status"`
+	ModerationKind   string `json:"moderation_kind"`
+	URL              string `json:"url"`
+	SecureURL        string `json:"secure_url"`
+}
+
+// EagerNotification is sent when the eager transformations of a file are generated.
+type EagerNotification struct {
+	Notification
+	AssetID  string        `json:"asset_id"`
+	PublicID string        `json:"public_id"`
+	BatchID  string        `json:"batch_id"`
+	Eager    []EagerResult `json:"eager"`
+}
+
+// EagerResult is a single derived file of an EagerNotification.
+type EagerResult struct {
+	Transformation string `json:"transformation"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	Bytes          int64  `json:"bytes"`
+	Format         string `json:"format"`
+	URL            string `json:"url"`
+	SecureURL      string `json:"secure_url"`
+}
+
+// The events below are dispatched by WebhookController, the listeners receive the disk name,
+// the public ID and the notification, e.g. Handle(disk string, publicID string, notification *UploadNotification).
+// They are dispatched as values, so register them as values too: cloudinary.AssetUploaded{}.
+type (
+	AssetUploaded  struct{}
+	AssetDeleted   struct{}
+	AssetModerated struct{}
+	EagerCompleted struct{}
+)
+
+func (r AssetUploaded) Handle(args []event.Arg) ([]event.Arg, error)  { return args, nil }
+func (r AssetDeleted) Handle(args []event.Arg) ([]event.Arg, error)   { return args, nil }
+func (r AssetModerated) Handle(args []event.Arg) ([]event.Arg, error) { return args, nil }
+func (r EagerCompleted) Handle(args []event.Arg) ([]event.Arg, error) { return args, nil }
+
+// defaultWebhookTolerance is how old a notification can be before it's rejected.
+const defaultWebhookTolerance = 2 * time.Hour
+
+// WebhookController receives the Cloudinary notifications and dispatches them as events:
+//
+//	"webhook": map[string]any{
+//		"prefix":     "cloudinary/webhook",
+//		"middleware": []http.Middleware{},
+//		"tolerance":  2 * time.Hour,
+//	},
+type WebhookController struct {
+	driver    *Cloudinary
+	events    event.Instance
+	tolerance time.Duration
+}
+
+func NewWebhookController(config config.Config, events event.Instance, disk string) (*WebhookController, error) {
+	driver, err := NewCloudinary(context.Background(), config, disk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookController{
+		driver:    driver,
+		events:    events,
+		tolerance: config.GetDuration(fmt.Sprintf("filesystems.disks.%s.webhook.tolerance", disk), defaultWebhookTolerance),
+	}, nil
+}
+
+// Handle verifies the notification signature and dispatches the notification.
+func (r *WebhookController) Handle(ctx http.Context) http.Response {
+	body, err := io.ReadAll(ctx.Request().Origin().Body)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": err.Error(),
+		})
+	}
+
+	if err := r.Verify(body, ctx.Request().Header("X-Cld-Timestamp"), ctx.Request().Header("X-Cld-Signature")); err != nil {
+		return ctx.Response().Json(http.StatusUnauthorized, http.Json{
+			"message": err.Error(),
+		})
+	}
+	if err := r.Dispatch(body); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, http.Json{
+			"message": err.Error(),
+		})
+	}
+
+	return ctx.Response().NoContent()
+}
+
+// Verify checks that the notification was signed with the disk secret and isn't stale.
+func (r *WebhookController) Verify(body []byte, timestamp, signature string) error {
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("missing notification signature")
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid notification timestamp: %s", timestamp)
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > r.tolerance || age < -r.tolerance {
+		return fmt.Errorf("stale notification timestamp: %s", timestamp)
+	}
+
+	cloud := r.driver.instance.Config.Cloud
+	expected, err := sign(string(body)+timestamp, cloud.APISecret, cloud.GetSignatureAlgorithm())
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("invalid notification signature")
+	}
+	return nil
+}
+
+// Dispatch parses the notification and dispatches the matching event, unknown notification types are ignored.
+func (r *WebhookController) Dispatch(body []byte) error {
+	var notification Notification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return err
+	}
+
+	switch notification.NotificationType {
+	case "upload":
+		var upload UploadNotification
+		if err := json.Unmarshal(body, &upload); err != nil {
+			return err
+		}
+		return r.dispatch(AssetUploaded{}, upload.PublicID, &upload)
+	case "delete":
+		var deletion DeleteNotification
+		if err := json.Unmarshal(body, &deletion); err != nil {
+			return err
+		}
+		for _, resource := range deletion.Resources {
+			if err := r.dispatch(AssetDeleted{}, resource.PublicID, &deletion); err != nil {
+				return err
+			}
+		}
+	case "moderation":
+		var moderation ModerationNotification
+		if err := json.Unmarshal(body, &moderation); err != nil {
+			return err
+		}
+		return r.dispatch(AssetModerated{}, moderation.PublicID, &moderation)
+	case "eager":
+		var eager EagerNotification
+		if err := json.Unmarshal(body, &eager); err != nil {
+			return err
+		}
+		eagerJobs.complete(&eager)
+		return r.dispatch(EagerCompleted{}, eager.PublicID, &eager)
+	}
+
+	return nil
+}
+
+// dispatch dispatches the event of a file, the files outside of the root of the disk are skipped. An event
+// without listeners isn't an error, otherwise Cloudinary would keep retrying the notification.
+func (r *WebhookController) dispatch(e event.Event, publicID string, notification any) error {
+	file, ok := relativePath(r.driver.root, publicID)
+	if !ok {
+		return nil
+	}
+
+	err := r.events.Job(e, []event.Arg{
+		{Type: "string", Value: r.driver.disk},
+		{Type: "string", Value: file},
+		{Type: fmt.Sprintf("%T", notification), Value: notification},
+	}).Dispatch()
+	if errors.Is(err, frameworkerrors.EventListenerNotBind) {
+		return nil
+	}
+	return err
+}
+
+// registerWebhookRoutes registers the notification endpoint of every disk that configures a webhook prefix.
+func registerWebhookRoutes(config config.Config, events event.Instance, router route.Route) error {
+	disks, _ := config.Get("filesystems.disks").(map[string]any)
+	for disk := range disks {
+		prefix := config.GetString(fmt.Sprintf("filesystems.disks.%s.webhook.prefix", disk))
+		if prefix == "" {
+			continue
+		}
+
+		controller, err := NewWebhookController(config, events, disk)
+		if err != nil {
+			return err
+		}
+		middleware, _ := config.Get(fmt.Sprintf("filesystems.disks.%s.webhook.middleware", disk)).([]http.Middleware)
+		router.Middleware(middleware...).Post(prefix, controller.Handle)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package cloudinary
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	frameworkerrors "github.com/goravel/framework/errors"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mocksevent "github.com/goravel/framework/mocks/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestWebhookController(t *testing.T, events event.Instance) *WebhookController {
+	mockConfig := mocksconfig.NewConfig(t)
+	mockDiskConfig(mockConfig, "cloudinary", map[string]any{"cloud": "cloud", "key": "key", "secret": "secret"})
+	mockConfig.EXPECT().GetDuration("filesystems.disks.cloudinary.webhook.tolerance", defaultWebhookTolerance).Return(time.Hour)
+
+	controller, err := NewWebhookController(mockConfig, events, "cloudinary")
+	assert.Nil(t, err)
+
+	return controller
+}
+
+func TestWebhookVerify(t *testing.T) {
+	controller := newTestWebhookController(t, nil)
+	body, err := os.ReadFile("testdata/webhooks/upload.json")
+	assert.Nil(t, err)
+
+	signature := func(body []byte, timestamp string) string {
+		sum := sha1.Sum(append(body, []byte(timestamp+"secret")...))
+		return hex.EncodeToString(sum[:])
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+
+	assert.Nil(t, controller.Verify(body, now, signature(body, now)))
+	assert.EqualError(t, controller.Verify(body, now, ""), "missing notification signature")
+	assert.EqualError(t, controller.Verify(body, "now", signature(body, now)), "invalid notification timestamp: now")
+	assert.EqualError(t, controller.Verify(body, stale, signature(body, stale)), "stale notification timestamp: "+stale)
+	assert.EqualError(t, controller.Verify(append(body, ' '), now, signature(body, now)), "invalid notification signature")
+}
+
+func TestWebhookDispatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		events  map[event.Event][]string
+		assert  func(notification any)
+	}{
+		{
+			name:    "upload",
+			payload: "upload.json",
+			events:  map[event.Event][]string{AssetUploaded{}: {"avatars/goravel"}},
+			assert: func(notification any) {
+				upload := notification.(*UploadNotification)
+				assert.Equal(t, "d7a1f6c0b2e34a5f8c9d0e1f2a3b4c5d", upload.AssetID)
+				assert.Equal(t, int64(1710236465), upload.Version)
+				assert.Equal(t, 512, upload.Width)
+				assert.Equal(t, "png", upload.Format)
+				assert.Equal(t, int64(20480), upload.Bytes)
+				assert.Equal(t, []string{"avatar"}, upload.Tags)
+				assert.Equal(t, time.Date(2024, 3, 12, 9, 41, 5, 0, time.UTC), upload.CreatedAt)
+			},
+		},
+		{
+			name:    "delete",
+			payload: "delete.json",
+			events:  map[event.Event][]string{AssetDeleted{}: {"avatars/goravel", "docs/readme.txt"}},
+			assert: func(notification any) {
+				deletion := notification.(*DeleteNotification)
+				assert.Len(t, deletion.Resources, 2)
+				assert.Equal(t, "raw", deletion.Resources[1].ResourceType)
+			},
+		},
+		{
+			name:    "moderation",
+			payload: "moderation.json",
+			events:  map[event.Event][]string{AssetModerated{}: {"avatars/goravel"}},
+			assert: func(notification any) {
+				moderation := notification.(*ModerationNotification)
+				assert.Equal(t, "approved", moderation.ModerationStatus)
+				assert.Equal(t, "manual", moderation.ModerationKind)
+			},
+		},
+		{
+			name:    "eager",
+			payload: "eager.json",
+			events:  map[event.Event][]string{EagerCompleted{}: {"avatars/goravel"}},
+			assert: func(notification any) {
+				eager := notification.(*EagerNotification)
+				assert.Len(t, eager.Eager, 1)
+				assert.Equal(t, "c_fill,h_100,w_100", eager.Eager[0].Transformation)
+				assert.NotEmpty(t, eager.BatchID)
+			},
+		},
+		{
+			name:    "unknown",
+			payload: "unknown.json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockEvent := mocksevent.NewInstance(t)
+			for e, publicIDs := range test.events {
+				for _, publicID := range publicIDs {
+					mockTask := mocksevent.NewTask(t)
+					mockTask.EXPECT().Dispatch().Return(nil).Once()
+					mockEvent.EXPECT().Job(e, []event.Arg{
+						{Type: "string", Value: "cloudinary"},
+						{Type: "string", Value: publicID},
+					}).Return(mockTask).Once()
+				}
+			}
+			controller := newTestWebhookController(t, mockEvent)
+			controller.events = &recordingEvents{Instance: mockEvent, assert: test.assert}
+
+			body, err := os.ReadFile("testdata/webhooks/" + test.payload)
+			assert.Nil(t, err)
+			assert.Nil(t, controller.Dispatch(body))
+		})
+	}
+}
+
+func TestWebhookDispatchSkipped(t *testing.T) {
+	body, err := os.ReadFile("testdata/webhooks/delete.json")
+	assert.Nil(t, err)
+
+	// The files outside of the root aren't dispatched.
+	mockEvent := mocksevent.NewInstance(t)
+	mockTask := mocksevent.NewTask(t)
+	mockTask.EXPECT().Dispatch().Return(nil).Once()
+	mockEvent.EXPECT().Job(AssetDeleted{}, []event.Arg{
+		{Type: "string", Value: "cloudinary"},
+		{Type: "string", Value: "readme.txt"},
+	}).Return(mockTask).Once()
+	controller := newTestWebhookController(t, mockEvent)
+	controller.driver.root = "docs"
+	controller.events = &recordingEvents{Instance: mockEvent, assert: func(any) {}}
+	assert.Nil(t, controller.Dispatch(body))
+
+	// An event without listeners isn't an error.
+	mockEvent = mocksevent.NewInstance(t)
+	mockTask = mocksevent.NewTask(t)
+	mockTask.EXPECT().Dispatch().Return(frameworkerrors.EventListenerNotBind.Args(AssetDeleted{})).Twice()
+	mockEvent.EXPECT().Job(AssetDeleted{}, mock.Anything).Return(mockTask).Twice()
+	controller = newTestWebhookController(t, mockEvent)
+	assert.Nil(t, controller.Dispatch(body))
+
+	mockEvent = mocksevent.NewInstance(t)
+	mockTask = mocksevent.NewTask(t)
+	mockTask.EXPECT().Dispatch().Return(errors.New("queue is down")).Once()
+	mockEvent.EXPECT().Job(AssetDeleted{}, mock.Anything).Return(mockTask).Once()
+	controller = newTestWebhookController(t, mockEvent)
+	assert.EqualError(t, controller.Dispatch(body), "queue is down")
+}
+
+// recordingEvents strips the notification from the event arguments, so the mock can match the
+// disk and public ID while the test asserts the parsed notification.
+type recordingEvents struct {
+	event.Instance
+	assert func(notification any)
+}
+
+func (r *recordingEvents) Job(e event.Event, args []event.Arg) event.Task {
+	r.assert(args[2].Value)
+	return r.Instance.Job(e, args[:2])
+}